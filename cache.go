@@ -0,0 +1,288 @@
+package directusapi
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CacheEntry is a cached response body of a read request
+// ETag and LastModified are validators returned by the server, they are used
+// to revalidate expired entries with a conditional request.
+type CacheEntry struct {
+	Body         []byte
+	StoredAt     time.Time
+	ETag         string
+	LastModified string
+}
+
+// CacheBackend is a storage used by ReadCache
+// Keys of a single collection share the same prefix, so the whole
+// collection can be invalidated with DeletePrefix.
+type CacheBackend interface {
+	Get(key string) (CacheEntry, bool)
+	Set(key string, entry CacheEntry)
+	DeletePrefix(prefix string)
+}
+
+// ReadCache is a read-through cache in front of GetByID and Items
+// Entries younger than TTL are served from the cache. Entries older than TTL
+// but younger than TTL+StaleTTL are served stale while they are refreshed
+// in the background. Writes done through the API invalidate the collection.
+// Expired entries with ETag or Last-Modified validators are revalidated with
+// a conditional request and 304 Not Modified renews them without a download.
+//
+// Backend defaults to MemoryCache holding defaultCacheCapacity entries.
+//
+// ReadCache can be shared by multiple API values, keys don't include
+// the bearer token, so share it only between clients with equal permissions.
+type ReadCache struct {
+	Backend  CacheBackend
+	TTL      time.Duration
+	StaleTTL time.Duration
+
+	initBackend sync.Once
+	mu          sync.Mutex
+	generation  uint64
+	refreshing  map[string]bool
+}
+
+const defaultCacheCapacity = 1000
+
+// fetchFunc fetches a fresh entry, prev is an expired entry to revalidate or nil
+type fetchFunc func(ctx context.Context, prev *CacheEntry) (CacheEntry, error)
+
+// load returns cached body for the key or calls fetch to get a fresh one
+func (c *ReadCache) load(ctx context.Context, key string, fetch fetchFunc) ([]byte, error) {
+	var prev *CacheEntry
+	if e, ok := c.backend().Get(key); ok {
+		age := time.Since(e.StoredAt)
+		if age < c.TTL {
+			return e.Body, nil
+		}
+		if age < c.TTL+c.StaleTTL {
+			c.revalidate(key, e, fetch)
+			return e.Body, nil
+		}
+		prev = &e
+	}
+
+	gen := c.currentGeneration()
+	e, err := fetch(ctx, prev)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, e, gen)
+	return e.Body, nil
+}
+
+// revalidate refreshes the key in the background, at most once at a time
+func (c *ReadCache) revalidate(key string, prev CacheEntry, fetch fetchFunc) {
+	c.mu.Lock()
+	if c.refreshing == nil {
+		c.refreshing = map[string]bool{}
+	}
+	if c.refreshing[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.refreshing[key] = true
+	gen := c.generation
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.refreshing, key)
+			c.mu.Unlock()
+		}()
+		e, err := fetch(context.Background(), &prev)
+		if err != nil {
+			// keep serving the stale entry until it expires
+			return
+		}
+		c.store(key, e, gen)
+	}()
+}
+
+// store saves the entry unless the cache was invalidated since gen was read
+func (c *ReadCache) store(key string, e CacheEntry, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != gen {
+		return
+	}
+	c.backend().Set(key, e)
+}
+
+// backend returns the configured backend or the default MemoryCache
+func (c *ReadCache) backend() CacheBackend {
+	c.initBackend.Do(func() {
+		if c.Backend == nil {
+			c.Backend = NewMemoryCache(defaultCacheCapacity)
+		}
+	})
+	return c.Backend
+}
+
+func (c *ReadCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+func (c *ReadCache) invalidate(prefix string) {
+	c.mu.Lock()
+	c.generation++
+	c.mu.Unlock()
+	c.backend().DeletePrefix(prefix)
+}
+
+// MemoryCache is an in-memory LRU CacheBackend
+type MemoryCache struct {
+	capacity int
+
+	mu    sync.Mutex
+	ll    *list.List
+	items map[string]*list.Element
+}
+
+type memoryCacheItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewMemoryCache creates LRU cache holding at most capacity entries
+func NewMemoryCache(capacity int) *MemoryCache {
+	return &MemoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    map[string]*list.Element{},
+	}
+}
+
+func (m *MemoryCache) Get(key string) (CacheEntry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	el, ok := m.items[key]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	m.ll.MoveToFront(el)
+	return el.Value.(*memoryCacheItem).entry, true
+}
+
+func (m *MemoryCache) Set(key string, entry CacheEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if el, ok := m.items[key]; ok {
+		el.Value.(*memoryCacheItem).entry = entry
+		m.ll.MoveToFront(el)
+		return
+	}
+	m.items[key] = m.ll.PushFront(&memoryCacheItem{key, entry})
+	for m.capacity > 0 && m.ll.Len() > m.capacity {
+		oldest := m.ll.Back()
+		m.ll.Remove(oldest)
+		delete(m.items, oldest.Value.(*memoryCacheItem).key)
+	}
+}
+
+func (m *MemoryCache) DeletePrefix(prefix string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, el := range m.items {
+		if strings.HasPrefix(key, prefix) {
+			m.ll.Remove(el)
+			delete(m.items, key)
+		}
+	}
+}
+
+// cachedRequest executes GET request through the read cache if it's configured
+func (a *API[R, W, PK]) cachedRequest(r request, dest any) error {
+	if a.Cache == nil {
+		return a.executeRequest(r, http.StatusOK, dest)
+	}
+
+	fetch := func(ctx context.Context, prev *CacheEntry) (CacheEntry, error) {
+		r.ctx = ctx
+		return a.conditionalRequest(r, prev)
+	}
+
+	body, err := a.Cache.load(r.ctx, a.cacheKey(r), fetch)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, dest); err != nil {
+		return fmt.Errorf("decoding json response: %w", err)
+	}
+	readIn(dest, a.Location)
+	return nil
+}
+
+// conditionalRequest executes GET request, if prev entry has validators
+// the request is conditional and 304 Not Modified renews the prev entry
+func (a *API[R, W, PK]) conditionalRequest(r request, prev *CacheEntry) (CacheEntry, error) {
+	header := http.Header{}
+	if prev != nil {
+		if prev.ETag != "" {
+			header.Set("If-None-Match", prev.ETag)
+		}
+		if prev.LastModified != "" {
+			header.Set("If-Modified-Since", prev.LastModified)
+		}
+	}
+
+	resp, err := a.sendRequest(r, header)
+	if err != nil {
+		return CacheEntry{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && prev != nil {
+		e := *prev
+		e.StoredAt = time.Now()
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			e.ETag = etag
+		}
+		return e, nil
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return CacheEntry{}, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return CacheEntry{}, newError(resp, body)
+	}
+	return CacheEntry{
+		Body:         body,
+		StoredAt:     time.Now(),
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+	}, nil
+}
+
+// cacheKeyPrefix is shared by all cache keys of the collection
+func (a *API[R, W, PK]) cacheKeyPrefix() string {
+	return fmt.Sprintf("%s://%s/%s/items/%s#", a.Scheme, a.Host, a.Namespace, a.CollectionName)
+}
+
+func (a *API[R, W, PK]) cacheKey(r request) string {
+	path := strings.TrimPrefix(r.url, strings.TrimSuffix(a.cacheKeyPrefix(), "#"))
+	return a.cacheKeyPrefix() + path + "?" + encodeQuery(r.qv)
+}
+
+// invalidateCache drops all cached reads of the collection
+func (a *API[R, W, PK]) invalidateCache() {
+	if a.Cache != nil {
+		a.Cache.invalidate(a.cacheKeyPrefix())
+	}
+}
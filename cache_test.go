@@ -0,0 +1,145 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCache(t *testing.T) {
+	var reads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			n := atomic.AddInt32(&reads, 1)
+			fmt.Fprintf(w, `{"data":{"id":1,"name":"apple %d"}}`, n)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	api := testAPI(srv)
+	api.Cache = &ReadCache{
+		Backend: NewMemoryCache(10),
+		TTL:     time.Minute,
+	}
+
+	apple, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "apple 1", apple.Name)
+
+	apple, err = api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "apple 1", apple.Name)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+
+	require.NoError(t, api.Delete(ctx, 1))
+
+	apple, err = api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "apple 2", apple.Name)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&reads))
+}
+
+func TestReadCacheStaleWhileRevalidate(t *testing.T) {
+	var reads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&reads, 1)
+		fmt.Fprintf(w, `{"data":[{"id":1,"name":"apple %d"}]}`, n)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	api := testAPI(srv)
+	api.Cache = &ReadCache{
+		Backend:  NewMemoryCache(10),
+		TTL:      time.Nanosecond,
+		StaleTTL: time.Hour,
+	}
+
+	fruits, err := api.Items(ctx, None())
+	require.NoError(t, err)
+	assert.Equal(t, "apple 1", fruits[0].Name)
+
+	// stale entry is served while it's refreshed in the background
+	fruits, err = api.Items(ctx, None())
+	require.NoError(t, err)
+	assert.Equal(t, "apple 1", fruits[0].Name)
+
+	assert.Eventually(t, func() bool {
+		fruits, err := api.Items(ctx, None())
+		return err == nil && fruits[0].Name != "apple 1"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	c := NewMemoryCache(2)
+	c.Set("a", CacheEntry{Body: []byte("a")})
+	c.Set("b", CacheEntry{Body: []byte("b")})
+	_, _ = c.Get("a")
+	c.Set("c", CacheEntry{Body: []byte("c")})
+
+	_, ok := c.Get("b")
+	assert.False(t, ok)
+	_, ok = c.Get("a")
+	assert.True(t, ok)
+
+	c.DeletePrefix("")
+	_, ok = c.Get("c")
+	assert.False(t, ok)
+}
+
+func TestReadCacheConditionalRequest(t *testing.T) {
+	var downloads, notModified int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"v1"`)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			atomic.AddInt32(&notModified, 1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		atomic.AddInt32(&downloads, 1)
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"apple"}]}`)
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	api := testAPI(srv)
+	api.Cache = &ReadCache{
+		Backend: NewMemoryCache(10),
+		TTL:     time.Nanosecond,
+	}
+
+	for i := 0; i < 3; i++ {
+		fruits, err := api.Items(ctx, None())
+		require.NoError(t, err)
+		assert.Equal(t, "apple", fruits[0].Name)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&downloads))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&notModified))
+}
+
+func TestReadCacheDefaultBackend(t *testing.T) {
+	var reads int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&reads, 1)
+		w.Write([]byte(`{"data":{"id":1,"name":"apple"}}`))
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	api.Cache = &ReadCache{TTL: time.Minute}
+	for i := 0; i < 2; i++ {
+		_, err := api.GetByID(context.Background(), 1)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&reads))
+}
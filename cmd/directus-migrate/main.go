@@ -0,0 +1,118 @@
+// Command directus-migrate applies versioned schema migrations to a Directus project
+//
+// Usage:
+//
+//	directus-migrate -url https://example.com/_ -dir migrations up
+//	directus-migrate -url https://example.com/_ -dir migrations -steps 1 down
+//	directus-migrate -url https://example.com/_ -dir migrations status
+//
+// Migrations are JSON files named <version>_<name>.json, see directusapi.LoadMigrations.
+// Bearer token is read from DIRECTUS_TOKEN.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/zdebra/directusapi"
+)
+
+func main() {
+	rawURL := flag.String("url", "", "project URL, e.g. https://example.com/_")
+	dir := flag.String("dir", "migrations", "directory with migration files")
+	collection := flag.String("collection", "", "collection recording applied versions, defaults to schema_migrations")
+	steps := flag.Int("steps", 0, "number of migrations to apply or revert, up applies all and down reverts one by default")
+	flag.Parse()
+
+	if *rawURL == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	c, err := client(*rawURL, os.Getenv("DIRECTUS_TOKEN"))
+	if err != nil {
+		fail("%v", err)
+	}
+	migrations, err := directusapi.LoadMigrations(os.DirFS(*dir))
+	if err != nil {
+		fail("load migrations: %v", err)
+	}
+	m := directusapi.Migrator{
+		Client:     c,
+		Migrations: migrations,
+		Collection: *collection,
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	switch flag.Arg(0) {
+	case "up":
+		done, err := m.Up(ctx, *steps)
+		for _, mig := range done {
+			fmt.Printf("applied %d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fail("up: %v", err)
+		}
+	case "down":
+		n := *steps
+		if n == 0 {
+			n = 1
+		}
+		done, err := m.Down(ctx, n)
+		for _, mig := range done {
+			fmt.Printf("reverted %d_%s\n", mig.Version, mig.Name)
+		}
+		if err != nil {
+			fail("down: %v", err)
+		}
+	case "status":
+		status, err := m.Status(ctx)
+		if err != nil {
+			fail("status: %v", err)
+		}
+		for _, s := range status {
+			if s.Applied {
+				fmt.Printf("[x] %d_%s applied on %s\n", s.Version, s.Name, s.AppliedOn.Format("2006-01-02 15:04:05"))
+			} else {
+				fmt.Printf("[ ] %d_%s\n", s.Version, s.Name)
+			}
+		}
+	default:
+		fail("unknown command %q, use up, down or status", flag.Arg(0))
+	}
+}
+
+// client creates client of the project URL, the path is the project namespace
+func client(rawURL, token string) (directusapi.Dynamic, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return directusapi.Dynamic{}, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return directusapi.Dynamic{}, fmt.Errorf("invalid project URL %q", rawURL)
+	}
+	namespace := strings.Trim(u.Path, "/")
+	if namespace == "" {
+		namespace = "_"
+	}
+	return directusapi.Dynamic{
+		Scheme:      u.Scheme,
+		Host:        u.Host,
+		Namespace:   namespace,
+		BearerToken: token,
+		HTTPClient:  http.DefaultClient,
+	}, nil
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
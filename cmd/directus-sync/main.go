@@ -0,0 +1,131 @@
+// Command directus-sync copies items of a collection between two Directus servers
+//
+// Usage:
+//
+//	directus-sync -source https://staging.example.com/_ -target https://example.com/_ \
+//		-collection fruits -key name -ignore modified_on,modified_by -dry-run
+//
+// Bearer tokens are read from DIRECTUS_SOURCE_TOKEN and DIRECTUS_TARGET_TOKEN.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+
+	"github.com/zdebra/directusapi"
+)
+
+func main() {
+	source := flag.String("source", "", "source project URL, e.g. https://staging.example.com/_")
+	target := flag.String("target", "", "target project URL, e.g. https://example.com/_")
+	collection := flag.String("collection", "", "collection to synchronize")
+	key := flag.String("key", "", "field matching items of both servers, defaults to the primary key")
+	pk := flag.String("pk", "id", "primary key field")
+	ignore := flag.String("ignore", "", "comma separated fields excluded from comparison")
+	deleteMissing := flag.Bool("delete", false, "delete target items missing in the source")
+	dryRun := flag.Bool("dry-run", false, "only print the change plan")
+	batch := flag.Int("batch", 100, "number of items written by a single request")
+	flag.Parse()
+
+	if *source == "" || *target == "" || *collection == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	src, err := client(*source, os.Getenv("DIRECTUS_SOURCE_TOKEN"), *pk)
+	if err != nil {
+		fail("source: %v", err)
+	}
+	dst, err := client(*target, os.Getenv("DIRECTUS_TARGET_TOKEN"), *pk)
+	if err != nil {
+		fail("target: %v", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	opts := directusapi.SyncOptions{
+		KeyField: *key,
+		Delete:   *deleteMissing,
+	}
+	if *ignore != "" {
+		opts.Ignore = strings.Split(*ignore, ",")
+	}
+	plan, err := directusapi.PlanSync(ctx, src.Collection(*collection), dst.Collection(*collection), opts)
+	if err != nil {
+		fail("plan: %v", err)
+	}
+	printPlan(plan)
+
+	if *dryRun {
+		return
+	}
+	res, err := directusapi.ApplySync(ctx, dst.Collection(*collection), plan, *batch)
+	for _, e := range res.Errors {
+		fmt.Fprintf(os.Stderr, "error: %v\n", e)
+	}
+	fmt.Printf("inserted %d, updated %d, deleted %d, failed %d\n", res.Inserted, res.Updated, res.Deleted, len(res.Errors))
+	if err != nil {
+		fail("apply: %v", err)
+	}
+	if len(res.Errors) > 0 || len(plan.Conflicts) > 0 {
+		os.Exit(1)
+	}
+}
+
+// client creates client of the project URL, the path is the project namespace
+func client(rawURL, token, pk string) (directusapi.Dynamic, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return directusapi.Dynamic{}, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return directusapi.Dynamic{}, fmt.Errorf("invalid project URL %q", rawURL)
+	}
+	namespace := strings.Trim(u.Path, "/")
+	if namespace == "" {
+		namespace = "_"
+	}
+	return directusapi.Dynamic{
+		Scheme:          u.Scheme,
+		Host:            u.Host,
+		Namespace:       namespace,
+		BearerToken:     token,
+		HTTPClient:      http.DefaultClient,
+		PrimaryKeyField: pk,
+	}, nil
+}
+
+func printPlan(plan directusapi.SyncPlan) {
+	for _, c := range plan.Inserts {
+		fmt.Printf("+ %s\n", c.Key)
+	}
+	for _, c := range plan.Updates {
+		fields := make([]string, 0, len(c.Values))
+		for f := range c.Values {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		fmt.Printf("~ %s: %s\n", c.Key, strings.Join(fields, ", "))
+	}
+	for _, c := range plan.Deletes {
+		fmt.Printf("- %s\n", c.Key)
+	}
+	for _, c := range plan.Conflicts {
+		fmt.Printf("! %s: %s\n", c.Key, c.Reason)
+	}
+	fmt.Printf("plan: %d to insert, %d to update, %d to delete, %d conflicts\n",
+		len(plan.Inserts), len(plan.Updates), len(plan.Deletes), len(plan.Conflicts))
+}
+
+func fail(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format+"\n", args...)
+	os.Exit(1)
+}
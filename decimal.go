@@ -0,0 +1,164 @@
+package directusapi
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// decimalPattern is a JSON number
+var decimalPattern = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// Decimal is an exact number for DECIMAL fields, it's kept as the text
+// received from the server, so no precision is lost. Both JSON numbers and
+// numeric strings are decoded, it's always encoded as a JSON number.
+// Zero value is 0.
+type Decimal struct {
+	text string
+}
+
+// NewDecimal parses decimal number, e.g. "120.36"
+func NewDecimal(s string) (Decimal, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "+")
+	if !decimalPattern.MatchString(s) {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Decimal{s}, nil
+}
+
+// MustDecimal parses decimal number and panics when it's invalid
+func MustDecimal(s string) Decimal {
+	d, err := NewDecimal(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// DecimalFromFloat converts f to the shortest decimal representing it,
+// NaN and infinities are rejected
+func DecimalFromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("invalid decimal %v", f)
+	}
+	return Decimal{strconv.FormatFloat(f, 'f', -1, 64)}, nil
+}
+
+// String returns the decimal as it was received or parsed
+func (d Decimal) String() string {
+	if d.text == "" {
+		return "0"
+	}
+	return d.text
+}
+
+// Float64 returns the nearest float, the precision may be lost
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// Rat returns the exact value, it's nil when the decimal isn't valid,
+// which can't happen for decimals made by the constructors
+func (d Decimal) Rat() *big.Rat {
+	r, ok := new(big.Rat).SetString(d.String())
+	if !ok {
+		return nil
+	}
+	return r
+}
+
+// Cmp compares values of decimals, it returns -1, 0 or +1
+// Invalid decimals are compared by their text.
+func (d Decimal) Cmp(other Decimal) int {
+	a, b := d.Rat(), other.Rat()
+	if a == nil || b == nil {
+		return strings.Compare(d.String(), other.String())
+	}
+	return a.Cmp(b)
+}
+
+// IsZero reports whether the value is zero, e.g. "0.00"
+func (d Decimal) IsZero() bool {
+	r := d.Rat()
+	return r != nil && r.Sign() == 0
+}
+
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		*d = Decimal{}
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		if s == "" {
+			*d = Decimal{}
+			return nil
+		}
+	}
+	parsed, err := NewDecimal(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Decimal) UnmarshalText(data []byte) error {
+	parsed, err := NewDecimal(string(data))
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner, DECIMAL columns are scanned as bytes
+func (d *Decimal) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case []byte:
+		return d.UnmarshalText(v)
+	case string:
+		return d.UnmarshalText([]byte(v))
+	case int64:
+		*d = Decimal{strconv.FormatInt(v, 10)}
+		return nil
+	case float64:
+		parsed, err := DecimalFromFloat(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("scan %T into directusapi.Decimal", src)
+	}
+}
+
+// Value implements driver.Valuer, the decimal is passed as a string
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+func (d Decimal) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
@@ -0,0 +1,64 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecimal(t *testing.T) {
+	var item struct {
+		Price  Decimal           `json:"price"`
+		Tax    Decimal           `json:"tax"`
+		Budget Optional[Decimal] `json:"budget"`
+		Fee    Decimal           `json:"fee"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"price": 12345678901234567890.123456789,
+		"tax": "0.21",
+		"budget": null,
+		"fee": null
+	}`), &item))
+	assert.Equal(t, "12345678901234567890.123456789", item.Price.String())
+	assert.Equal(t, "0.21", item.Tax.String())
+	assert.False(t, item.Budget.IsSet())
+	assert.True(t, item.Fee.IsZero())
+	assert.Equal(t, big.NewRat(21, 100), item.Tax.Rat())
+	assert.Equal(t, 0.21, item.Tax.Float64())
+
+	b, err := json.Marshal(item)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"price":12345678901234567890.123456789,"tax":0.21,"budget":null,"fee":0}`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`"12,5"`), &item.Tax))
+	assert.Error(t, json.Unmarshal([]byte(`true`), &item.Tax))
+
+	assert.Equal(t, 0, MustDecimal("1.50").Cmp(MustDecimal("1.5")))
+	tenth, err := DecimalFromFloat(0.1)
+	require.NoError(t, err)
+	assert.Equal(t, -1, tenth.Cmp(MustDecimal("0.11")))
+	for _, f := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		_, err = DecimalFromFloat(f)
+		assert.Error(t, err)
+	}
+	assert.Error(t, new(Decimal).Scan(math.Inf(1)))
+	invalid := Decimal{"+Inf"}
+	assert.False(t, invalid.IsZero())
+	assert.Nil(t, invalid.Rat())
+	assert.Equal(t, -1, invalid.Cmp(MustDecimal("1")))
+	_, err = NewDecimal("1/3")
+	assert.Error(t, err)
+
+	var d Decimal
+	require.NoError(t, d.Scan([]byte("120.36")))
+	assert.Equal(t, "120.36", d.String())
+
+	fields, err := fieldsOf(reflect.TypeOf(item))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"price", "tax", "budget", "fee"}, fields)
+}
@@ -0,0 +1,134 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Diff compares two write models and returns fields of after which differ from before
+// Fields are compared by their JSON encoding, a nested struct, slice or map
+// is returned as a whole when anything in it changed. Untouched (noop)
+// Optional fields of after aren't changes, other fields omitted from its
+// encoding (e.g. emptied omitempty fields) are returned with their empty value.
+// Numbers are returned as json.Number.
+func Diff[W any](before, after W) (map[string]any, error) {
+	return encoder{}.diff(before, after)
+}
+
+// UpdateDiff performs partial update of an item with given id, only fields
+// changed between before and after are sent. When nothing changed the item is read.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (d API[R, W, PK]) UpdateDiff(ctx context.Context, id PK, before, after W) (R, error) {
+	var empty R
+	partials, err := d.encoder().diff(before, after)
+	if err != nil {
+		return empty, fmt.Errorf("diff items: %w", err)
+	}
+	if len(partials) == 0 {
+		return d.GetByID(ctx, id)
+	}
+	return d.Update(ctx, id, partials)
+}
+
+func (e encoder) diff(before, after any) (map[string]any, error) {
+	beforeFields, err := e.encodeObject(before)
+	if err != nil {
+		return nil, err
+	}
+	afterFields, err := e.encodeObject(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := map[string]any{}
+	for name, a := range afterFields {
+		if b, ok := beforeFields[name]; ok && bytes.Equal(a, b) {
+			continue
+		}
+		v, err := decodeGeneric(a)
+		if err != nil {
+			return nil, err
+		}
+		changed[name] = v
+	}
+	for name := range beforeFields {
+		if _, ok := afterFields[name]; ok {
+			continue
+		}
+		v, cleared, err := e.clearedField(reflect.ValueOf(after), name)
+		if err != nil {
+			return nil, err
+		}
+		if cleared {
+			changed[name] = v
+		}
+	}
+	return changed, nil
+}
+
+// clearedField returns value of the field omitted from the encoding of item,
+// e.g. emptied omitempty field. Noop Optional fields are untouched, so they
+// aren't cleared.
+func (e encoder) clearedField(item reflect.Value, name string) (any, bool, error) {
+	for item.Kind() == reflect.Pointer || item.Kind() == reflect.Interface {
+		if item.IsNil() {
+			return nil, true, nil
+		}
+		item = item.Elem()
+	}
+	if item.Kind() != reflect.Struct {
+		// missing map key
+		return nil, true, nil
+	}
+	for _, f := range encodedFieldsOf(item.Type()) {
+		if f.name != name {
+			continue
+		}
+		fv, ok := fieldByIndex(item, f.index)
+		if !ok {
+			// field of nil embedded struct
+			return nil, true, nil
+		}
+		if f.optional && fv.Interface().(isOpt).getOp() == noop {
+			return nil, false, nil
+		}
+		el, err := e.encodeField(f, fv)
+		if err != nil {
+			return nil, false, err
+		}
+		b, err := json.Marshal(el)
+		if err != nil {
+			return nil, false, err
+		}
+		v, err := decodeGeneric(b)
+		return v, true, err
+	}
+	return nil, true, nil
+}
+
+// encodeObject encodes the item into its fields, map keys are sorted
+// by encoding/json so encodings of equal values are equal
+func (e encoder) encodeObject(item any) (map[string]json.RawMessage, error) {
+	doc, err := e.encode(reflect.ValueOf(item))
+	if err != nil {
+		return nil, err
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%T isn't encoded as JSON object", item)
+	}
+	out := make(map[string]json.RawMessage, len(obj))
+	for name, v := range obj {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = b
+	}
+	return out, nil
+}
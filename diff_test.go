@@ -0,0 +1,84 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	before := FruitW{
+		Name:      "apple",
+		Weight:    1,
+		Price:     SetOptional(1.5),
+		Area:      []string{"europe"},
+		Favorites: map[string]string{"josef": "10"},
+	}
+	after := before
+	after.Weight = 2
+	after.Price = UnsetOptional[float64]()
+	after.Area = []string{"europe", "africa"}
+	after.Favorites = map[string]string{"josef": "10"}
+
+	changed, err := Diff(before, after)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"weight": json.Number("2"),
+		"price":  nil,
+		"area":   []any{"europe", "africa"},
+	}, changed)
+
+	changed, err = Diff(before, before)
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	type Inner struct {
+		Name string `json:"name"`
+	}
+	type shadowed struct {
+		Name string `json:"name"`
+		Inner
+	}
+	changed, err = Diff(shadowed{Name: "a"}, shadowed{Name: "b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "b"}, changed)
+
+	type cleared struct {
+		Name  string           `json:"name,omitempty"`
+		Seeds int              `json:"seeds,omitzero"`
+		Ripe  Optional[bool]   `json:"ripe"`
+		Color Optional[string] `json:"color,omitempty"`
+	}
+	changed, err = Diff(
+		cleared{Name: "apple", Seeds: 3, Ripe: SetOptional(true), Color: SetOptional("red")},
+		cleared{},
+	)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "", "seeds": json.Number("0")}, changed)
+
+	_, err = Diff(1, 2)
+	assert.Error(t, err)
+}
+
+func TestUpdateDiff(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"pear"}`, string(body))
+		}
+		fmt.Fprint(w, `{"data":{"id":1,"name":"pear"}}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	pear, err := api.UpdateDiff(context.Background(), 1, FruitW{Name: "apple"}, FruitW{Name: "pear"})
+	require.NoError(t, err)
+	assert.Equal(t, "pear", pear.Name)
+}
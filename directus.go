@@ -2,29 +2,47 @@ package directusapi
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// upsertRetries is a number of lookups repeated after a unique constraint error
+const upsertRetries = 3
+
+// maxFieldDepth limits nesting of reflected fields, so recursive types fail instead of looping
+const maxFieldDepth = 10
+
 type PrimaryKey interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~string
 }
 
-// API is a generic API client for any Directus collection
+// API is a generic API client for any Directus collection, it's safe for concurrent use
 // R is a read model
 // W is a write model
 // PK is a type of primary key
 type API[R, W any, PK PrimaryKey] struct {
-	Scheme         string
-	Host           string
-	Namespace      string
-	CollectionName string
-	BearerToken    string
-	HTTPClient     *http.Client
-	queryFields    []string
-	debug          bool
+	Scheme          string
+	Host            string
+	Namespace       string
+	CollectionName  string
+	BearerToken     string
+	HTTPClient      *http.Client
+	Cache           *ReadCache     // optional read-through cache for GetByID and Items
+	PrimaryKeyField string         // name of the primary key field, defaults to "id"
+	VersionField    string         // field checked by SetIfVersion and UpdateIfVersion, defaults to "modified_on"
+	StatusField     string         // status field used by SoftDelete and Restore, defaults to "status"
+	DeletedStatus   string         // status with soft_delete flag, defaults to "deleted"
+	RestoredStatus  string         // status set by Restore, defaults to "draft"
+	Location        *time.Location // time zone of datetimes without offset, defaults to UTC
+	NullZeroTime    bool           // zero directusapi.Time is written as null
+	debug           bool
 }
 
 // CreateToken uses provided credentials to generate server token
@@ -70,26 +88,62 @@ func (d API[R, W, PK]) CreateToken(ctx context.Context, email, password string)
 func (d API[R, W, PK]) Insert(ctx context.Context, item W) (R, error) {
 	var empty R
 	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPost,
 		u,
 		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
+			"fields": strings.Join(fields, ","),
 		},
 		item,
 	}
 	var respBody struct {
 		Data R `json:"data"`
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
 	if err != nil {
 		return empty, fmt.Errorf("execute insert request: %w", err)
 	}
 	return respBody.Data, nil
 }
 
+// InsertMany attempts to insert new items in a single request
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#create-an-item
+func (d API[R, W, PK]) InsertMany(ctx context.Context, items []W) ([]R, error) {
+	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return nil, err
+	}
+
+	req := request{
+		ctx,
+		http.MethodPost,
+		u,
+		map[string]string{
+			"fields": strings.Join(fields, ","),
+		},
+		items,
+	}
+	var respBody struct {
+		Data []R `json:"data"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
+	if err != nil {
+		return nil, fmt.Errorf("execute insert many request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
 // Create attempts to create new item with partials
 //
 // Related Directus reference:
@@ -97,13 +151,17 @@ func (d API[R, W, PK]) Insert(ctx context.Context, item W) (R, error) {
 func (d API[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R, error) {
 	var empty R
 	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPost,
 		u,
 		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
+			"fields": strings.Join(fields, ","),
 		},
 		partials,
 	}
@@ -111,7 +169,8 @@ func (d API[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R,
 	var respBody struct {
 		Data R `json:"data"`
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
 	if err != nil {
 		return empty, fmt.Errorf("execute create request: %w", err)
 	}
@@ -124,27 +183,49 @@ func (d API[R, W, PK]) Create(ctx context.Context, partials map[string]any) (R,
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#retrieve-an-item
 func (d API[R, W, PK]) GetByID(ctx context.Context, id PK) (R, error) {
+	return GetByIDAs[R](ctx, d, id, None())
+}
+
+// GetByIDAs reads a single item by given ID into T instead of the read model
+// Fields, depth, aliases and language of the query select what is read,
+// fields are reflected from T by default. Filters of the query are ignored.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#retrieve-an-item
+func GetByIDAs[T any, R, W any, PK PrimaryKey](ctx context.Context, d API[R, W, PK], id PK, q query) (T, error) {
 	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	var item T
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return item, err
+	}
+	qv := map[string]string{
+		"fields": q.selectFields(fields),
+	}
+	if q.lang != nil {
+		qv["lang"] = *q.lang
+	}
 
 	req := request{
 		ctx,
 		http.MethodGet,
 		u,
-		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
-		},
+		qv,
 		nil,
 	}
 
 	var respBody struct {
-		Data R `json:"data"`
+		Data json.RawMessage `json:"data"`
 	}
-	var empty R
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.cachedRequest(req, &respBody)
 	if err != nil {
-		return empty, fmt.Errorf("execute get by id request: %w", err)
+		return item, fmt.Errorf("execute get by id request: %w", err)
 	}
-	return respBody.Data, nil
+	if err := q.project(respBody.Data, &item); err != nil {
+		return item, fmt.Errorf("decoding item: %w", err)
+	}
+	readIn(&item, d.Location)
+	return item, nil
 }
 
 // Update performs partial update of an item with given id
@@ -154,13 +235,17 @@ func (d API[R, W, PK]) GetByID(ctx context.Context, id PK) (R, error) {
 func (d API[R, W, PK]) Update(ctx context.Context, id PK, partials map[string]any) (R, error) {
 	var empty R
 	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPatch,
 		u,
 		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
+			"fields": strings.Join(fields, ","),
 		},
 		partials,
 	}
@@ -168,27 +253,65 @@ func (d API[R, W, PK]) Update(ctx context.Context, id PK, partials map[string]an
 	var respBody struct {
 		Data R `json:"data"`
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
 	if err != nil {
 		return empty, fmt.Errorf("execute update request: %w", err)
 	}
 	return respBody.Data, nil
 }
 
+// UpdateMany performs partial updates of multiple items in a single request,
+// each of partials has to contain the primary key of the updated item
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-multiple-items
+func (d API[R, W, PK]) UpdateMany(ctx context.Context, partials []map[string]any) ([]R, error) {
+	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return nil, err
+	}
+
+	req := request{
+		ctx,
+		http.MethodPatch,
+		u,
+		map[string]string{
+			"fields": strings.Join(fields, ","),
+		},
+		partials,
+	}
+	var respBody struct {
+		Data []R `json:"data"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
+	if err != nil {
+		return nil, fmt.Errorf("execute update many request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
 // Set performs an update of an item with given id
+// Untouched (noop) Optional fields of the item are omitted and left unchanged.
 //
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#update-an-item
 func (d API[R, W, PK]) Set(ctx context.Context, id PK, item W) (R, error) {
 	var empty R
 	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return empty, err
+	}
 
 	req := request{
 		ctx,
 		http.MethodPatch,
 		u,
 		map[string]string{
-			"fields": strings.Join(d.jsonFieldsR(), ","),
+			"fields": strings.Join(fields, ","),
 		},
 		item,
 	}
@@ -196,13 +319,127 @@ func (d API[R, W, PK]) Set(ctx context.Context, id PK, item W) (R, error) {
 	var respBody struct {
 		Data R `json:"data"`
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	d.invalidateCache()
 	if err != nil {
 		return empty, fmt.Errorf("execute set request: %w", err)
 	}
 	return respBody.Data, nil
 }
 
+// SetIfVersion performs an update of an item with given id only if its
+// version field still holds the expected value read before, otherwise
+// *ConflictError is returned. The version is checked right before the update,
+// writes bypassing this check can still interleave.
+func (d API[R, W, PK]) SetIfVersion(ctx context.Context, id PK, version any, item W) (R, error) {
+	var empty R
+	if err := d.checkVersion(ctx, id, version); err != nil {
+		return empty, err
+	}
+	return d.Set(ctx, id, item)
+}
+
+// UpdateIfVersion performs partial update of an item with given id only if
+// its version field still holds the expected value, see SetIfVersion
+func (d API[R, W, PK]) UpdateIfVersion(ctx context.Context, id PK, version any, partials map[string]any) (R, error) {
+	var empty R
+	if err := d.checkVersion(ctx, id, version); err != nil {
+		return empty, err
+	}
+	return d.Update(ctx, id, partials)
+}
+
+// ErrConflict is matched by *ConflictError with errors.Is
+var ErrConflict = errors.New("item was modified")
+
+// ConflictError is returned when the item was modified since it was read
+type ConflictError struct {
+	ID       string
+	Field    string
+	Expected string
+	Actual   string
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("item %s was modified: %s is %q, expected %q", e.ID, e.Field, e.Actual, e.Expected)
+}
+
+func (e *ConflictError) Is(target error) bool {
+	return target == ErrConflict
+}
+
+func (d API[R, W, PK]) versionField() string {
+	if d.VersionField == "" {
+		return "modified_on"
+	}
+	return d.VersionField
+}
+
+// checkVersion compares version field of a stored item with the expected value
+func (d API[R, W, PK]) checkVersion(ctx context.Context, id PK, version any) error {
+	u := fmt.Sprintf("%s://%s/%s/items/%s/%v", d.Scheme, d.Host, d.Namespace, d.CollectionName, id)
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		map[string]string{
+			"fields": d.versionField(),
+		},
+		nil,
+	}
+
+	var respBody struct {
+		Data map[string]json.RawMessage `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return fmt.Errorf("execute get version request: %w", err)
+	}
+
+	actualDoc, err := decodeGeneric(respBody.Data[d.versionField()])
+	if err != nil {
+		return err
+	}
+	actual, err := formatCell(actualDoc)
+	if err != nil {
+		return err
+	}
+	b, err := d.encoder().marshal(version)
+	if err != nil {
+		return err
+	}
+	expectedDoc, err := decodeGeneric(b)
+	if err != nil {
+		return err
+	}
+	expected, err := formatCell(expectedDoc)
+	if err != nil {
+		return err
+	}
+	if actual != expected && !sameInstant(actual, expected, d.Location) {
+		return &ConflictError{
+			ID:       fmt.Sprint(id),
+			Field:    d.versionField(),
+			Expected: expected,
+			Actual:   actual,
+		}
+	}
+	return nil
+}
+
+// sameInstant reports whether both values are datetimes of the same instant,
+// the server may format them differently, e.g. in RFC 3339
+func sameInstant(a, b string, loc *time.Location) bool {
+	var ta, tb Time
+	if ta.UnmarshalText([]byte(a)) != nil || tb.UnmarshalText([]byte(b)) != nil {
+		return false
+	}
+	readIn(&ta, loc)
+	readIn(&tb, loc)
+	return ta.Equal(tb.Time)
+}
+
 // Delete removes item with a given id
 //
 // Related Directus reference:
@@ -218,20 +455,101 @@ func (d API[R, W, PK]) Delete(ctx context.Context, id PK) error {
 	}
 
 	err := d.executeRequest(req, http.StatusNoContent, nil)
+	d.invalidateCache()
 	if err != nil {
 		return fmt.Errorf("execute delete request: %w", err)
 	}
 	return nil
 }
 
+// DeleteMany removes items with given ids in a single request
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#delete-an-item
+func (d API[R, W, PK]) DeleteMany(ctx context.Context, ids []PK) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	idStrs := make([]string, len(ids))
+	for i, id := range ids {
+		idStrs[i] = fmt.Sprint(id)
+	}
+	u := fmt.Sprintf("%s://%s/%s/items/%s/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName, strings.Join(idStrs, ","))
+	req := request{
+		ctx,
+		http.MethodDelete,
+		u,
+		nil,
+		nil,
+	}
+
+	err := d.executeRequest(req, http.StatusNoContent, nil)
+	d.invalidateCache()
+	if err != nil {
+		return fmt.Errorf("execute delete many request: %w", err)
+	}
+	return nil
+}
+
+// SoftDelete sets the status of an item with given id to the deleted status
+// Items with a soft_delete status are hidden from Items unless the query
+// asks for them with Status.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (d API[R, W, PK]) SoftDelete(ctx context.Context, id PK) (R, error) {
+	deleted := d.DeletedStatus
+	if deleted == "" {
+		deleted = "deleted"
+	}
+	return d.setStatus(ctx, id, deleted)
+}
+
+// Restore sets the status of a soft deleted item with given id to the restored status
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (d API[R, W, PK]) Restore(ctx context.Context, id PK) (R, error) {
+	restored := d.RestoredStatus
+	if restored == "" {
+		restored = "draft"
+	}
+	return d.setStatus(ctx, id, restored)
+}
+
+func (d API[R, W, PK]) setStatus(ctx context.Context, id PK, status string) (R, error) {
+	field := d.StatusField
+	if field == "" {
+		field = "status"
+	}
+	return d.Update(ctx, id, map[string]any{
+		field: status,
+	})
+}
+
 // Items retrieves a collection of items
 //
 // Related Directus reference:
 // https://v8.docs.directus.io/api/items.html#update-an-item
 func (d API[R, W, PK]) Items(ctx context.Context, q query) ([]R, error) {
+	return ItemsAs[R](ctx, d, q)
+}
+
+// ItemsAs retrieves a collection of items decoded into T instead of the read model,
+// so one API can serve both shallow and deeply expanded views of the collection.
+// Fields, depth and aliases of the query select what is read, fields
+// are reflected from T by default.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#list-the-items
+func ItemsAs[T any, R, W any, PK PrimaryKey](ctx context.Context, d API[R, W, PK], q query) ([]T, error) {
 	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := fieldsOf(reflect.TypeOf((*T)(nil)).Elem())
+	if err != nil {
+		return nil, err
+	}
 	qv := q.asKeyValue()
-	qv["fields"] = strings.Join(d.jsonFieldsR(), ",")
+	qv["fields"] = q.selectFields(fields)
 
 	req := request{
 		ctx,
@@ -241,96 +559,392 @@ func (d API[R, W, PK]) Items(ctx context.Context, q query) ([]R, error) {
 		nil,
 	}
 	var respBody struct {
-		Data []R `json:"data"`
+		Data []json.RawMessage `json:"data"`
 	}
-	err := d.executeRequest(req, http.StatusOK, &respBody)
+	err = d.cachedRequest(req, &respBody)
 	if err != nil {
 		return nil, fmt.Errorf("execute items request: %w", err)
 	}
-	return respBody.Data, nil
+	items := make([]T, len(respBody.Data))
+	for i, raw := range respBody.Data {
+		if err := q.project(raw, &items[i]); err != nil {
+			return nil, fmt.Errorf("decoding item %d: %w", i, err)
+		}
+	}
+	readIn(&items, d.Location)
+	return items, nil
 }
 
-func (d *API[R, W, PK]) jsonFieldsR() []string {
-	if d.queryFields == nil {
-		var x R
-		t := reflect.TypeOf(x)
-		d.queryFields = iterateFields(t, "")
-	}
-	return d.queryFields
+// UpsertResult is a result of an upsert of a single item
+type UpsertResult[R any] struct {
+	Item    R
+	Created bool
 }
 
-// iterateFields returns fields for all struct's fields
-func iterateFields(t reflect.Type, prefix string) []string {
-	fields := []string{}
-	for i := 0; i < t.NumField(); i++ {
-		f := t.Field(i)
-		fields = append(fields, structFields(f, prefix)...)
+// Upsert inserts the item or updates an existing one with the same
+// value of the unique keyField, created reports which one happened
+func (d API[R, W, PK]) Upsert(ctx context.Context, keyField string, item W) (R, bool, error) {
+	var empty R
+	res, err := d.UpsertMany(ctx, keyField, []W{item})
+	if err != nil {
+		return empty, false, err
 	}
-	return fields
+	return res[0].Item, res[0].Created, nil
 }
 
-// structFields returns fields for a signle struct field
-func structFields(f reflect.StructField, prefix string) []string {
-	tagVal := ""
-	if v, ok := f.Tag.Lookup(tagName); ok {
-		tagVal = v
-	} else {
-		tagVal = f.Name
+// UpsertMany inserts the items or updates existing ones with the same
+// value of the unique keyField. New items are inserted by a single request.
+// When a concurrent writer inserts the same key first, the unique constraint
+// error is handled by another lookup and the item is updated instead.
+// Results are in the order of items.
+func (d API[R, W, PK]) UpsertMany(ctx context.Context, keyField string, items []W) ([]UpsertResult[R], error) {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		key, err := fieldString(item, keyField)
+		if err != nil {
+			return nil, fmt.Errorf("item %d: %w", i, err)
+		}
+		keys[i] = key
 	}
-	switch f.Type.Kind() {
-	case reflect.Struct:
-		var t Time
-		isTime := f.Type.ConvertibleTo(reflect.TypeOf(t))
-		isOptional := f.Type.Implements(reflect.TypeOf(new(isOpt)).Elem())
-		switch {
-		case isOptional:
-			val := reflect.New(f.Type).Interface().(isOpt)
-			if prefix == "" {
-				return val.fields(tagVal)
-			} else {
-				return val.fields(prefix + "." + tagVal)
+
+	res := make([]UpsertResult[R], len(items))
+	pending := make([]int, len(items))
+	for i := range items {
+		pending[i] = i
+	}
+
+	for attempt := 0; ; attempt++ {
+		pendingKeys := make([]string, len(pending))
+		for i, idx := range pending {
+			pendingKeys[i] = keys[idx]
+		}
+		existing, err := d.existingByKey(ctx, keyField, pendingKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		inserts := []int{}
+		for _, idx := range pending {
+			pk, ok := existing[keys[idx]]
+			if !ok {
+				inserts = append(inserts, idx)
+				continue
 			}
-		case isTime:
-			return []string{prefix}
-		default:
-			p := prefix
-			if p != "" {
-				p = p + "." + tagVal
-			} else {
-				p = tagVal
+			item, err := d.Set(ctx, pk, items[idx])
+			if err != nil {
+				return nil, fmt.Errorf("update item %s=%s: %w", keyField, keys[idx], err)
 			}
-			return iterateFields(f.Type, p)
+			res[idx] = UpsertResult[R]{item, false}
+		}
+		if len(inserts) == 0 {
+			return res, nil
+		}
+
+		newItems := make([]W, len(inserts))
+		for i, idx := range inserts {
+			newItems[i] = items[idx]
+		}
+		created, err := d.InsertMany(ctx, newItems)
+		if isUniqueViolation(err) && attempt < upsertRetries {
+			pending = inserts
+			continue
 		}
-	case reflect.Slice:
-		p := prefix
-		if p != "" {
-			p = p + "." + tagVal
+		if err != nil {
+			return nil, err
+		}
+		if len(created) != len(inserts) {
+			return nil, fmt.Errorf("inserted %d items, expected %d", len(created), len(inserts))
+		}
+		for i, idx := range inserts {
+			res[idx] = UpsertResult[R]{created[i], true}
+		}
+		return res, nil
+	}
+}
+
+// existingByKey finds primary keys of stored items by values of the unique keyField
+// Keys are read bypassing the cache, keys containing a comma can't be
+// part of the "in" filter and are looked up one by one.
+func (d API[R, W, PK]) existingByKey(ctx context.Context, keyField string, keys []string) (map[string]PK, error) {
+	uncached := d
+	uncached.Cache = nil
+
+	var plain []string
+	var queries []query
+	for _, key := range keys {
+		if strings.Contains(key, ",") {
+			queries = append(queries, Eq(keyField, key).Limit(1))
 		} else {
-			p = tagVal
+			plain = append(plain, key)
+		}
+	}
+	if len(plain) > 0 {
+		queries = append(queries, In(keyField, strings.Join(plain, ",")).Limit(len(plain)))
+	}
+
+	out := make(map[string]PK, len(keys))
+	for _, q := range queries {
+		items, err := uncached.Items(ctx, q)
+		if err != nil {
+			return nil, fmt.Errorf("read existing items: %w", err)
+		}
+		if err := d.collectKeys(items, keyField, out); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// collectKeys adds primary keys of items by values of keyField to out
+func (d API[R, W, PK]) collectKeys(items []R, keyField string, out map[string]PK) error {
+	for _, item := range items {
+		key, err := fieldString(item, keyField)
+		if err != nil {
+			return err
+		}
+		pk, err := d.primaryKey(item)
+		if err != nil {
+			return err
+		}
+		out[key] = pk
+	}
+	return nil
+}
+
+// StreamItems retrieves a collection of items and calls fn for each item
+// as it's decoded from the response, so the whole result set is never held
+// in memory. Iteration stops with the first error returned by fn.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#list-the-items
+func (d API[R, W, PK]) StreamItems(ctx context.Context, q query, fn func(R) error) error {
+	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return err
+	}
+	qv := q.asKeyValue()
+	qv["fields"] = q.selectFields(fields)
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		qv,
+		nil,
+	}
+	err = d.streamRequest(req, func(dec *json.Decoder) error {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("decoding item: %w", err)
+		}
+		var item R
+		if err := q.project(raw, &item); err != nil {
+			return fmt.Errorf("decoding item: %w", err)
+		}
+		readIn(&item, d.Location)
+		return fn(item)
+	})
+	if err != nil {
+		return fmt.Errorf("execute stream items request: %w", err)
+	}
+	return nil
+}
+
+func (d API[R, W, PK]) pkField() string {
+	if d.PrimaryKeyField == "" {
+		return "id"
+	}
+	return d.PrimaryKeyField
+}
+
+// primaryKey reads primary key of an item
+func (d API[R, W, PK]) primaryKey(item R) (PK, error) {
+	var pk PK
+	doc, err := toGeneric(item)
+	if err != nil {
+		return pk, err
+	}
+	v := lookupPath(doc, []string{d.pkField()})
+	if v == nil {
+		return pk, fmt.Errorf("item has no primary key %s", d.pkField())
+	}
+	s, err := formatCell(v)
+	if err != nil {
+		return pk, err
+	}
+	return parsePK[PK](s)
+}
+
+func (d API[R, W, PK]) jsonFieldsR() ([]string, error) {
+	return fieldsOf(reflect.TypeOf((*R)(nil)).Elem())
+}
+
+// reflectedFields are fields of models shared by all API instances
+var reflectedFields typeCache[fieldsResult]
+
+type fieldsResult struct {
+	fields []string
+	err    error
+}
+
+// typeCache is a concurrency-safe cache of metadata reflected from types
+type typeCache[V any] struct {
+	m sync.Map
+}
+
+// load returns cached metadata of t, it's reflected by fn on the first use
+func (c *typeCache[V]) load(t reflect.Type, fn func(reflect.Type) V) V {
+	if v, ok := c.m.Load(t); ok {
+		return v.(V)
+	}
+	v, _ := c.m.LoadOrStore(t, fn(t))
+	return v.(V)
+}
+
+// fieldsOf returns fields of a read model, all fields are selected for non-struct models
+// The result is cached and shared, it must not be modified.
+func fieldsOf(t reflect.Type) ([]string, error) {
+	res := reflectedFields.load(t, func(t reflect.Type) fieldsResult {
+		fields, err := reflectFields(t)
+		// full slice expression makes appends copy the shared fields
+		return fieldsResult{fields[:len(fields):len(fields)], err}
+	})
+	return res.fields, res.err
+}
+
+func reflectFields(t reflect.Type) ([]string, error) {
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return []string{"*"}, nil
+	}
+	fields, err := iterateFields(t, "")
+	if err != nil {
+		return nil, fmt.Errorf("fields of %s: %w", t, err)
+	}
+	return fields, nil
+}
+
+// iterateFields returns fields for all struct's fields
+func iterateFields(t reflect.Type, prefix string) ([]string, error) {
+	fields := []string{}
+	for _, f := range jsonFields(t, isFlattened) {
+		ff, err := structFields(f, prefix)
+		if err != nil {
+			return nil, err
 		}
-		if f.Type.Elem().Kind() == reflect.Struct {
-			return iterateFields(f.Type.Elem(), p)
+		fields = append(fields, ff...)
+	}
+	return fields, nil
+}
+
+// isFlattened reports whether fields of the embedded struct are selected
+// as fields of the outer struct, embedded leaves and fields with
+// directus tag are selected as a single field.
+func isFlattened(f reflect.StructField) bool {
+	t := f.Type
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return f.Tag.Get(directusTagName) == "" && !isLeaf(t)
+}
+
+// structFields returns fields for a signle struct field resolved by encoding/json
+// rules. The directus tag overrides the selection: "-" skips the field,
+// "leaf" selects the field itself and a comma separated list selects its sub-fields.
+func structFields(f jsonField, prefix string) ([]string, error) {
+	path := f.name
+	if prefix != "" {
+		path = prefix + "." + f.name
+	}
+
+	switch override := f.Tag.Get(directusTagName); override {
+	case "":
+		return typeFields(f.Type, path)
+	case "-":
+		return nil, nil
+	case "leaf":
+		return []string{path}, nil
+	default:
+		fields := []string{}
+		for _, sub := range strings.Split(override, ",") {
+			fields = append(fields, path+"."+strings.TrimSpace(sub))
 		}
-		return []string{p}
+		return fields, nil
+	}
+}
+
+// isLeaf reports whether values of t decode themselves and are selected as a single field
+func isLeaf(t reflect.Type) bool {
+	if _, ok := reflect.New(t).Interface().(fieldLister); ok {
+		return true
+	}
+	return reflect.PointerTo(t).Implements(jsonUnmarshalerType) ||
+		reflect.PointerTo(t).Implements(textUnmarshalerType)
+}
+
+// typeFields returns fields for a value of type t under path,
+// pointers and slices are selected as their elements
+func typeFields(t reflect.Type, path string) ([]string, error) {
+	if strings.Count(path, ".") >= maxFieldDepth {
+		return nil, fmt.Errorf("%s: nested deeper than %d levels, recursive types are not supported", path, maxFieldDepth)
+	}
+	if fl, ok := reflect.New(t).Interface().(fieldLister); ok {
+		// types like Optional know their fields
+		return fl.fields(path)
+	}
+	if isLeaf(t) {
+		// types decoding themselves (e.g. json.RawMessage) are a single field
+		return []string{path}, nil
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		return iterateFields(t, path)
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		return typeFields(t.Elem(), path)
 	case
-		reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Interface, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr, reflect.Float32, reflect.Float64,
 		reflect.String, reflect.Map:
 		// field is not nested
-		v := tagVal
-		if prefix != "" {
-			v = prefix + "." + tagVal
+		return []string{path}, nil
+	default:
+		return nil, fmt.Errorf("%s: %s is not supported", path, t.Kind())
+	}
+}
+
+// parsePK parses primary key from its string representation
+func parsePK[PK PrimaryKey](s string) (PK, error) {
+	var pk PK
+	v := reflect.ValueOf(&pk).Elem()
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, v.Type().Bits())
+		if err != nil {
+			return pk, fmt.Errorf("parse primary key %q: %w", s, err)
 		}
-		return []string{v}
-	case reflect.Pointer:
-		t := f.Type.Elem().String()
-		panic(f.Name + "(" + t + "," + prefix + "): pointer is not supported, use directus.Optional instead")
+		v.SetInt(n)
 	default:
-		panic(f.Type.Kind().String() + " not implemented")
+		n, err := strconv.ParseUint(s, 10, v.Type().Bits())
+		if err != nil {
+			return pk, fmt.Errorf("parse primary key %q: %w", s, err)
+		}
+		v.SetUint(n)
 	}
+	return pk, nil
+}
+
+// fieldLister is implemented by types selecting their own fields
+type fieldLister interface {
+	fields(prefix string) ([]string, error)
 }
 
 type isOpt interface {
+	fieldLister
 	getOp() operation
-	fields(prefix string) []string
+	elemType() reflect.Type
 }
@@ -1,9 +1,21 @@
 package directusapi
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestJsonFields(t *testing.T) {
@@ -25,6 +37,391 @@ func TestJsonFields(t *testing.T) {
 		"poc.id",
 		"poc.email",
 	}
-	jsonFields := api.jsonFieldsR()
+	jsonFields, err := api.jsonFieldsR()
+	require.NoError(t, err)
 	assert.Equal(t, expected, jsonFields)
 }
+
+func TestPointerFields(t *testing.T) {
+	type node struct {
+		ID     int    `json:"id"`
+		Parent *node  `json:"parent"`
+		Tags   []*int `json:"tags"`
+	}
+	type fruitP struct {
+		ID       int      `json:"id"`
+		Name     *string  `json:"name"`
+		Poc      *UserR   `json:"poc"`
+		Owners   []*UserR `json:"owners"`
+		Planted  *Time    `json:"planted"`
+		Channel  chan int `json:"channel"`
+		Recursed node     `json:"node"`
+	}
+	type fruitOK struct {
+		ID      int      `json:"id"`
+		Name    *string  `json:"name"`
+		Poc     *UserR   `json:"poc"`
+		Owners  []*UserR `json:"owners"`
+		Planted *Time    `json:"planted"`
+	}
+
+	fields, err := fieldsOf(reflect.TypeOf(fruitOK{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id", "name", "poc.id", "poc.email", "owners.id", "owners.email", "planted"}, fields)
+
+	_, err = fieldsOf(reflect.TypeOf(fruitP{}))
+	assert.ErrorContains(t, err, "channel: chan is not supported")
+	_, err = fieldsOf(reflect.TypeOf(node{}))
+	assert.ErrorContains(t, err, "recursive types are not supported")
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("request of invalid model is sent")
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	api := API[fruitP, fruitP, int]{
+		Scheme:         u.Scheme,
+		Host:           u.Host,
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient:     srv.Client(),
+	}
+	_, err = api.GetByID(context.Background(), 1)
+	assert.Error(t, err)
+}
+
+func TestFieldsFollowJSONRules(t *testing.T) {
+	type Audit struct {
+		CreatedOn Time `json:"created_on"`
+		internal  string
+	}
+	type fruit struct {
+		Audit
+		*UserR   `json:"owner"`
+		ID       int             `json:"id,omitempty"`
+		Name     string          `json:",omitempty"`
+		Secret   string          `json:"-"`
+		Extra    json.RawMessage `json:"extra"`
+		Meta     any             `json:"meta"`
+		Sizes    [3]int          `json:"sizes"`
+		Poc      UserR           `json:"poc" directus:"leaf"`
+		Farmer   UserR           `json:"farmer" directus:"id, *"`
+		Ignored  UserR           `json:"ignored" directus:"-"`
+		Harvests []UserR         `json:"harvests"`
+		note     string
+	}
+
+	fields, err := fieldsOf(reflect.TypeOf(fruit{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"created_on",
+		"owner.id",
+		"owner.email",
+		"id",
+		"Name",
+		"extra",
+		"meta",
+		"sizes",
+		"poc",
+		"farmer.id",
+		"farmer.*",
+		"harvests.id",
+		"harvests.email",
+	}, fields)
+
+	type Named struct {
+		Name string `json:"name"`
+		Note string `json:"note"`
+	}
+	type shadowed struct {
+		Name string `json:"name"`
+		Named
+	}
+	fields, err = fieldsOf(reflect.TypeOf(shadowed{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "note"}, fields)
+}
+
+func TestPointerPayload(t *testing.T) {
+	type fruitP struct {
+		ID   int     `json:"id"`
+		Name *string `json:"name"`
+		Poc  *UserR  `json:"poc"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"id":0,"name":"apple","poc":null}`, string(body))
+		fmt.Fprint(w, `{"data":{"id":1,"name":"apple","poc":null}}`)
+	}))
+	defer srv.Close()
+	u, _ := url.Parse(srv.URL)
+	api := API[fruitP, fruitP, int]{
+		Scheme:         u.Scheme,
+		Host:           u.Host,
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient:     srv.Client(),
+	}
+	name := "apple"
+	apple, err := api.Insert(context.Background(), fruitP{Name: &name})
+	require.NoError(t, err)
+	assert.Equal(t, "apple", *apple.Name)
+	assert.Nil(t, apple.Poc)
+}
+
+func testAPI(srv *httptest.Server) API[FruitR, FruitW, int] {
+	u, _ := url.Parse(srv.URL)
+	return API[FruitR, FruitW, int]{
+		Scheme:         u.Scheme,
+		Host:           u.Host,
+		Namespace:      "_",
+		CollectionName: "fruits",
+		HTTPClient:     srv.Client(),
+	}
+}
+
+func TestStreamItems(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"apple"},{"id":2,"name":"pear"}],"meta":{"total_count":2}}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	names := []string{}
+	err := api.StreamItems(context.Background(), None(), func(f FruitR) error {
+		names = append(names, f.Name)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"apple", "pear"}, names)
+
+	stop := errors.New("stop")
+	calls := 0
+	err = api.StreamItems(context.Background(), None(), func(f FruitR) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestUpsertRetriesOnDuplicate(t *testing.T) {
+	lookups := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			lookups++
+			if lookups == 1 {
+				fmt.Fprint(w, `{"data":[]}`)
+				return
+			}
+			// concurrent writer inserted the item meanwhile
+			fmt.Fprint(w, `{"data":[{"id":4,"name":"apple"}]}`)
+		case http.MethodPost:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"code":204,"message":"Duplicate key for record"}}`)
+		case http.MethodPatch:
+			assert.Equal(t, "/_/items/fruits/4", r.URL.Path)
+			fmt.Fprint(w, `{"data":{"id":4,"name":"apple","weight":3}}`)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	apple, created, err := api.Upsert(context.Background(), "name", FruitW{Name: "apple", Weight: 3})
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 4, apple.ID)
+	assert.Equal(t, 2, lookups)
+}
+
+func TestUpsertCommaKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "a,b", r.URL.Query().Get("filter[name][eq]"))
+			fmt.Fprint(w, `{"data":[{"id":4,"name":"a,b"}]}`)
+		case http.MethodPatch:
+			assert.Equal(t, "/_/items/fruits/4", r.URL.Path)
+			fmt.Fprint(w, `{"data":{"id":4,"name":"a,b"}}`)
+		default:
+			t.Errorf("unexpected %s request", r.Method)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	cache := &ReadCache{TTL: time.Minute}
+	api.Cache = cache
+	_, created, err := api.Upsert(context.Background(), "name", FruitW{Name: "a,b"})
+	require.NoError(t, err)
+	assert.False(t, created)
+	// lookups bypass the cache
+	assert.Zero(t, cache.backend().(*MemoryCache).ll.Len())
+}
+
+func TestSetIfVersion(t *testing.T) {
+	patches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "modified_on", r.URL.Query().Get("fields"))
+			fmt.Fprint(w, `{"data":{"modified_on":"2022-05-05 10:30:00"}}`)
+		case http.MethodPatch:
+			patches++
+			fmt.Fprint(w, `{"data":{"id":1,"name":"apple"}}`)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	ctx := context.Background()
+
+	_, err := api.SetIfVersion(ctx, 1, Time{time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)}, FruitW{Name: "apple"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, patches)
+
+	_, err = api.UpdateIfVersion(ctx, 1, "2022-05-05 10:00:00", map[string]any{"name": "pear"})
+	assert.ErrorIs(t, err, ErrConflict)
+	var conflict *ConflictError
+	require.ErrorAs(t, err, &conflict)
+	assert.Equal(t, "2022-05-05 10:30:00", conflict.Actual)
+	assert.Equal(t, 1, patches)
+}
+
+func TestSetIfVersionRFC3339(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			fmt.Fprint(w, `{"data":{"modified_on":"2022-05-05T10:30:00+00:00"}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":{"id":1,"name":"apple"}}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	ctx := context.Background()
+
+	var read Time
+	require.NoError(t, json.Unmarshal([]byte(`"2022-05-05T10:30:00+00:00"`), &read))
+	_, err := api.SetIfVersion(ctx, 1, read, FruitW{Name: "apple"})
+	require.NoError(t, err)
+
+	_, err = api.SetIfVersion(ctx, 1, Time{time.Date(2022, 5, 5, 10, 31, 0, 0, time.UTC)}, FruitW{Name: "apple"})
+	assert.ErrorIs(t, err, ErrConflict)
+}
+
+func TestItemsAs(t *testing.T) {
+	var fields string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fields = r.URL.Query().Get("fields")
+		if r.URL.Path == "/_/items/fruits/1" {
+			fmt.Fprint(w, `{"data":{"id":1,"name":"apple","lefield":{"id":3,"email":"a@example.com"}}}`)
+			return
+		}
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"apple","lefield":{"id":3,"email":"a@example.com"}}]}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	ctx := context.Background()
+
+	type fruitListItem struct {
+		ID         int    `json:"id"`
+		Name       string `json:"name"`
+		OwnerEmail string `json:"owner_email"`
+	}
+	fruits, err := ItemsAs[fruitListItem](ctx, api, Alias("owner_email", "lefield.email"))
+	require.NoError(t, err)
+	assert.Equal(t, "id,name,lefield.email", fields)
+	assert.Equal(t, []fruitListItem{{1, "apple", "a@example.com"}}, fruits)
+
+	type fruitRef struct {
+		ID      int `json:"id"`
+		LeField int `json:"lefield"`
+	}
+	_, _ = ItemsAs[fruitRef](ctx, api, None())
+	assert.Equal(t, "id,lefield", fields)
+
+	_, _ = api.Items(ctx, Depth(0))
+	assert.Equal(t, "id,name,weight,status,category,enabled,price,discovered_at,area,favorites,lefield,poc", fields)
+
+	apple, err := GetByIDAs[map[string]any](ctx, api, 1, Fields("*.*"))
+	require.NoError(t, err)
+	assert.Equal(t, "*.*", fields)
+	assert.Equal(t, "apple", apple["name"])
+}
+
+func TestSoftDeleteAndRestore(t *testing.T) {
+	bodies := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "published,deleted", r.URL.Query().Get("status"))
+			fmt.Fprint(w, `{"data":[]}`)
+		case http.MethodPatch:
+			b, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			fmt.Fprint(w, `{"data":{"id":1}}`)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	ctx := context.Background()
+	_, err := api.SoftDelete(ctx, 1)
+	require.NoError(t, err)
+	_, err = api.Items(ctx, Status("published", "deleted"))
+	require.NoError(t, err)
+	api.RestoredStatus = "published"
+	_, err = api.Restore(ctx, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{`{"status":"deleted"}`, `{"status":"published"}`}, bodies)
+}
+
+func TestSetOmitsNoopOptional(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"name":"apple","weight":1,"status":"","category":"","enabled":false,
+			"discovered_at":"0001-01-01 00:00:00","area":null,"favorites":null,"lefield":0,"poc":null}`, string(body))
+		fmt.Fprint(w, `{"data":{"id":1,"name":"apple"}}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	_, err := api.Set(context.Background(), 1, FruitW{
+		Name:   "apple",
+		Weight: 1,
+		PocID:  UnsetOptional[int](),
+	})
+	require.NoError(t, err)
+}
+
+func TestConcurrentUse(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Query().Get("fields"), "lefield.email")
+		fmt.Fprint(w, `{"data":[{"id":1,"name":"apple"}]}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	api.Cache = &ReadCache{Backend: NewMemoryCache(10), TTL: time.Minute}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := api.Items(context.Background(), Limit(1))
+			assert.NoError(t, err)
+			assert.Len(t, items, 1)
+		}()
+	}
+	wg.Wait()
+
+	a, err := fieldsOf(reflect.TypeOf(FruitR{}))
+	require.NoError(t, err)
+	b, err := fieldsOf(reflect.TypeOf(FruitR{}))
+	require.NoError(t, err)
+	assert.Same(t, &a[0], &b[0], "fields are reflected once")
+}
@@ -0,0 +1,150 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Dynamic is an API client for collections unknown at compile time
+// Items are represented as Record and all fields are read by default.
+type Dynamic struct {
+	Scheme          string
+	Host            string
+	Namespace       string
+	BearerToken     string
+	HTTPClient      *http.Client
+	Cache           *ReadCache     // optional read-through cache shared by all collections
+	PrimaryKeyField string         // name of the primary key field, defaults to "id"
+	Location        *time.Location // time zone of the server, defaults to UTC
+	NullZeroTime    bool           // zero directusapi.Time is written as null
+	debug           bool
+}
+
+// Collection returns API client of the collection with given name
+func (c Dynamic) Collection(name string) API[Record, Record, string] {
+	return API[Record, Record, string]{
+		Scheme:          c.Scheme,
+		Host:            c.Host,
+		Namespace:       c.Namespace,
+		CollectionName:  name,
+		BearerToken:     c.BearerToken,
+		HTTPClient:      c.HTTPClient,
+		Cache:           c.Cache,
+		PrimaryKeyField: c.PrimaryKeyField,
+		Location:        c.Location,
+		NullZeroTime:    c.NullZeroTime,
+		debug:           c.debug,
+	}
+}
+
+// Record is an item of any collection, numbers are kept as json.Number
+type Record map[string]any
+
+func (r *Record) UnmarshalJSON(data []byte) error {
+	doc, err := decodeGeneric(data)
+	if err != nil {
+		return err
+	}
+	if doc == nil {
+		*r = nil
+		return nil
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return &json.UnmarshalTypeError{Value: string(data), Type: recordType}
+	}
+	*r = obj
+	return nil
+}
+
+// String returns value of the string field
+func (r Record) String(field string) (string, bool) {
+	v, ok := r[field].(string)
+	return v, ok
+}
+
+// Int returns value of the integer field, numeric strings are accepted
+func (r Record) Int(field string) (int64, bool) {
+	switch v := r[field].(type) {
+	case json.Number:
+		n, err := v.Int64()
+		return n, err == nil
+	case float64:
+		return int64(v), float64(int64(v)) == v
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Float returns value of the number field, numeric strings are accepted
+func (r Record) Float(field string) (float64, bool) {
+	switch v := r[field].(type) {
+	case json.Number:
+		n, err := v.Float64()
+		return n, err == nil
+	case float64:
+		return v, true
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		return n, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// Bool returns value of the boolean field
+func (r Record) Bool(field string) (bool, bool) {
+	v, ok := r[field].(bool)
+	return v, ok
+}
+
+// Time returns value of the datetime field, values without a time zone are UTC
+func (r Record) Time(field string) (Time, bool) {
+	return r.TimeIn(field, nil)
+}
+
+// TimeIn returns value of the datetime field, values without a time zone
+// are read in loc, e.g. Dynamic.Location
+func (r Record) TimeIn(field string, loc *time.Location) (Time, bool) {
+	var t Time
+	s, ok := r[field].(string)
+	if !ok {
+		return t, false
+	}
+	if err := t.UnmarshalText([]byte(s)); err != nil {
+		return t, false
+	}
+	readIn(&t, loc)
+	return t, true
+}
+
+// Record returns value of the expanded many-to-one relation field
+func (r Record) Record(field string) (Record, bool) {
+	v, ok := r[field].(map[string]any)
+	return v, ok
+}
+
+// Records returns value of the expanded one-to-many relation field
+func (r Record) Records(field string) ([]Record, bool) {
+	list, ok := r[field].([]any)
+	if !ok {
+		return nil, false
+	}
+	out := make([]Record, 0, len(list))
+	for _, el := range list {
+		v, ok := el.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		out = append(out, v)
+	}
+	return out, true
+}
+
+var recordType = reflect.TypeOf(Record{})
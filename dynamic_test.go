@@ -0,0 +1,61 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamic(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/_/items/vegetables", r.URL.Path)
+		assert.Equal(t, "*.*", r.URL.Query().Get("fields"))
+		assert.Equal(t, "green", r.URL.Query().Get("filter[color][eq]"))
+		fmt.Fprint(w, `{"data":[{"id":9007199254740993,"name":"kale","weight":"1.5","fresh":true,
+			"planted_on":"2022-05-05 10:30:00","farmer":{"id":1},"tags":[{"id":2},{"id":3}]}]}`)
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	c := Dynamic{
+		Scheme:     u.Scheme,
+		Host:       u.Host,
+		Namespace:  "_",
+		HTTPClient: srv.Client(),
+	}
+	items, err := c.Collection("vegetables").Items(context.Background(), Eq("color", "green").Fields("*.*"))
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	kale := items[0]
+
+	id, ok := kale.Int("id")
+	assert.True(t, ok)
+	assert.Equal(t, int64(9007199254740993), id)
+	name, _ := kale.String("name")
+	assert.Equal(t, "kale", name)
+	weight, _ := kale.Float("weight")
+	assert.Equal(t, 1.5, weight)
+	fresh, _ := kale.Bool("fresh")
+	assert.True(t, fresh)
+	planted, ok := kale.Time("planted_on")
+	assert.True(t, ok)
+	assert.Equal(t, 2022, planted.Year())
+	prague := time.FixedZone("CEST", 2*60*60)
+	planted, ok = kale.TimeIn("planted_on", prague)
+	assert.True(t, ok)
+	assert.True(t, time.Date(2022, 5, 5, 8, 30, 0, 0, time.UTC).Equal(planted.Time))
+	farmer, ok := kale.Record("farmer")
+	assert.True(t, ok)
+	farmerID, _ := farmer.Int("id")
+	assert.Equal(t, int64(1), farmerID)
+	tags, ok := kale.Records("tags")
+	assert.True(t, ok)
+	assert.Len(t, tags, 2)
+}
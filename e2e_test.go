@@ -147,7 +147,7 @@ func TestFlow(t *testing.T) {
 		assert.Equal(t, "pasionfruit", pasionfruit.Name)
 		assert.Equal(t, 10, pasionfruit.Weight.ValueMust())
 		assert.Equal(t, "published", pasionfruit.Status)
-		assert.Equal(t, SetOptional[float64](0), pasionfruit.Price) // limitation of directus where DECIMAL cannot be null if not set
+		assert.Equal(t, SetOptional(120.36), pasionfruit.Price) // untouched optional field is kept
 		assert.Equal(t, SetOptional(UserR{ID: 1, Email: "email@example.com"}), pasionfruit.Poc)
 	})
 
@@ -0,0 +1,374 @@
+package directusapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// encoder converts request bodies into JSON trees following encoding/json
+// rules, types implementing bodyEncoder are encoded according to the API configuration.
+// Struct fields of noop Optional are omitted, so untouched fields of write
+// models aren't changed on the server.
+type encoder struct {
+	loc          *time.Location
+	nullZeroTime bool
+}
+
+// bodyEncoder is implemented by types encoded depending on the API configuration
+type bodyEncoder interface {
+	encodeBody(e encoder) (any, error)
+}
+
+var (
+	jsonMarshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+func (a *API[R, W, PK]) encoder() encoder {
+	return encoder{
+		loc:          a.Location,
+		nullZeroTime: a.NullZeroTime,
+	}
+}
+
+// marshal encodes the request body
+func (e encoder) marshal(body any) ([]byte, error) {
+	doc, err := e.encode(reflect.ValueOf(body))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(doc)
+}
+
+// encode returns JSON tree of v made of maps, slices and json.RawMessage leaves
+func (e encoder) encode(v reflect.Value) (any, error) {
+	if !v.IsValid() {
+		return nil, nil
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil, nil
+		}
+		return e.encode(v.Elem())
+	}
+
+	if be, ok := v.Interface().(bodyEncoder); ok {
+		return be.encodeBody(e)
+	}
+	if v.Type().Implements(jsonMarshalerType) || v.Type().Implements(textMarshalerType) {
+		return rawJSON(v.Interface())
+	}
+	if v.CanAddr() && (reflect.PointerTo(v.Type()).Implements(jsonMarshalerType) || reflect.PointerTo(v.Type()).Implements(textMarshalerType)) {
+		return rawJSON(v.Addr().Interface())
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		out := map[string]any{}
+		if err := e.encodeFields(v, out); err != nil {
+			return nil, err
+		}
+		return out, nil
+	case reflect.Map:
+		if v.IsNil() {
+			return nil, nil
+		}
+		if v.Type().Key().Kind() != reflect.String || v.Type().Key().Implements(textMarshalerType) {
+			return rawJSON(v.Interface())
+		}
+		out := make(map[string]any, v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			el, err := e.encode(iter.Value())
+			if err != nil {
+				return nil, err
+			}
+			out[iter.Key().String()] = el
+		}
+		return out, nil
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			// byte slices are base64 encoded
+			return rawJSON(v.Interface())
+		}
+		out := make([]any, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			el, err := e.encode(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			out[i] = el
+		}
+		return out, nil
+	default:
+		return rawJSON(v.Interface())
+	}
+}
+
+// encodeFields adds fields of the struct to out, embedded structs are flattened
+func (e encoder) encodeFields(v reflect.Value, out map[string]any) error {
+	for _, f := range encodedFieldsOf(v.Type()) {
+		fv, ok := fieldByIndex(v, f.index)
+		if !ok {
+			// field of nil embedded struct
+			continue
+		}
+		if f.omitEmpty && isEmptyValue(fv) {
+			continue
+		}
+		if f.omitZero && isZeroValue(fv) {
+			continue
+		}
+		if f.optional && fv.Interface().(isOpt).getOp() == noop {
+			continue
+		}
+
+		el, err := e.encodeField(f, fv)
+		if err != nil {
+			return err
+		}
+		out[f.name] = el
+	}
+	return nil
+}
+
+// encodeField encodes value of the struct field regardless of omit options
+func (e encoder) encodeField(f encodedField, fv reflect.Value) (any, error) {
+	el, err := e.encode(fv)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", f.goName, err)
+	}
+	if raw, ok := el.(json.RawMessage); ok && f.quoted {
+		el = json.RawMessage(strconv.Quote(string(raw)))
+	}
+	return el, nil
+}
+
+// fieldByIndex returns the nested field, it's false when an embedded pointer is nil
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, true
+}
+
+// encodedField is metadata of a struct field reflected from its json tag
+type encodedField struct {
+	index     []int
+	name      string
+	goName    string
+	omitEmpty bool
+	omitZero  bool
+	quoted    bool // ",string" option
+	optional  bool
+}
+
+var encodedFields typeCache[[]encodedField]
+
+// encodedFieldsOf returns encoded fields of the struct type including
+// fields of embedded structs
+func encodedFieldsOf(t reflect.Type) []encodedField {
+	return encodedFields.load(t, func(t reflect.Type) []encodedField {
+		fields := []encodedField{}
+		for _, f := range jsonFields(t, func(reflect.StructField) bool { return true }) {
+			_, opts := parseTag(f.Tag.Get(tagName))
+			_, optional := reflect.New(f.Type).Elem().Interface().(isOpt)
+			fields = append(fields, encodedField{
+				index:     f.index,
+				name:      f.name,
+				goName:    f.Name,
+				omitEmpty: opts.contains("omitempty"),
+				omitZero:  opts.contains("omitzero"),
+				quoted:    opts.contains("string") && isQuotable(f.Type.Kind()),
+				optional:  optional,
+			})
+		}
+		return fields
+	})
+}
+
+// jsonField is a struct field visible in JSON, index leads to it through embedded structs
+type jsonField struct {
+	reflect.StructField
+	name   string
+	index  []int
+	tagged bool
+}
+
+// jsonFields returns fields of the struct type following encoding/json rules.
+// Embedded structs are flattened when flatten reports true, unexported fields,
+// unexported embedded pointers and fields tagged "-" are left out.
+// Of fields with the same name the shallowest one wins, then the tagged one,
+// the remaining ambiguous fields are dropped.
+func jsonFields(t reflect.Type, flatten func(reflect.StructField) bool) []jsonField {
+	type embedded struct {
+		typ   reflect.Type
+		index []int
+	}
+	fields := []jsonField{}
+	visited := map[reflect.Type]bool{}
+	next := []embedded{{t, nil}}
+	for len(next) > 0 {
+		current := next
+		next = nil
+		// types embedded at shallower levels are dominant, their fields are already collected
+		level := map[reflect.Type]bool{}
+		for _, em := range current {
+			if visited[em.typ] {
+				continue
+			}
+			level[em.typ] = true
+			for i := 0; i < em.typ.NumField(); i++ {
+				sf := em.typ.Field(i)
+				tag := sf.Tag.Get(tagName)
+				if tag == "-" {
+					continue
+				}
+				name, _ := parseTag(tag)
+				index := append(em.index[:len(em.index):len(em.index)], i)
+
+				if sf.Anonymous && name == "" {
+					ft := sf.Type
+					isPointer := ft.Kind() == reflect.Pointer
+					if isPointer {
+						ft = ft.Elem()
+					}
+					if ft.Kind() == reflect.Struct && flatten(sf) {
+						// unexported pointers can't be followed, unexported
+						// structs are flattened as they may have exported fields
+						if !isPointer || sf.IsExported() {
+							next = append(next, embedded{ft, index})
+						}
+						continue
+					}
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				tagged := name != ""
+				if !tagged {
+					name = sf.Name
+				}
+				fields = append(fields, jsonField{sf, name, index, tagged})
+			}
+		}
+		for typ := range level {
+			visited[typ] = true
+		}
+	}
+
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+	dominant := []jsonField{}
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		first := fields[i]
+		if j == i+1 || len(first.index) < len(fields[i+1].index) || first.tagged != fields[i+1].tagged {
+			dominant = append(dominant, first)
+		}
+		i = j
+	}
+	sort.Slice(dominant, func(i, j int) bool {
+		a, b := dominant[i].index, dominant[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	return dominant
+}
+
+func rawJSON(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return json.RawMessage(b), nil
+}
+
+type tagOptions string
+
+// parseTag splits json tag into the name and options
+func parseTag(tag string) (string, tagOptions) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, tagOptions(opts)
+}
+
+func (o tagOptions) contains(opt string) bool {
+	for _, s := range strings.Split(string(o), ",") {
+		if s == opt {
+			return true
+		}
+	}
+	return false
+}
+
+func isQuotable(k reflect.Kind) bool {
+	switch k {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// isZeroValue reports whether the value is omitted by omitzero,
+// IsZero method is used when the type has one
+func isZeroValue(v reflect.Value) bool {
+	if z, ok := v.Interface().(interface{ IsZero() bool }); ok {
+		if v.Kind() == reflect.Pointer && v.IsNil() {
+			return true
+		}
+		return z.IsZero()
+	}
+	return v.IsZero()
+}
+
+// isEmptyValue reports whether the value is omitted by omitempty
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool:
+		return !v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return v.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return v.Float() == 0
+	case reflect.Interface, reflect.Pointer:
+		return v.IsNil()
+	}
+	return false
+}
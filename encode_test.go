@@ -0,0 +1,92 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoder(t *testing.T) {
+	type Audit struct {
+		Note string `json:"note"`
+	}
+	type item struct {
+		Audit
+		Name     string           `json:"name"`
+		Color    string           `json:"color,omitempty"`
+		Ripe     Optional[bool]   `json:"ripe"`
+		Seeds    Optional[int]    `json:"seeds"`
+		Origin   Optional[string] `json:"origin,omitzero"`
+		Harvest  Time             `json:"harvest,omitzero"`
+		Count    int              `json:"count,string"`
+		Internal string           `json:"-"`
+		Tags     []Optional[int]  `json:"tags"`
+		hidden   string
+	}
+
+	b, err := encoder{}.marshal(item{
+		Audit: Audit{Note: "fresh"},
+		Name:  "apple",
+		Ripe:  UnsetOptional[bool](),
+		Count: 3,
+		Tags:  []Optional[int]{SetOptional(1), {}},
+	})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"note":"fresh","name":"apple","ripe":null,"count":"3","tags":[1,null]}`, string(b))
+
+	type base struct {
+		ID   int    `json:"id"`
+		Note string `json:"note"`
+	}
+	type Origin struct {
+		Country string `json:"country"`
+	}
+	type embeds struct {
+		base
+		*Origin
+		Name string
+	}
+	b, err = encoder{}.marshal(embeds{base: base{1, "x"}, Name: "n"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":1,"note":"x","Name":"n"}`, string(b))
+
+	type hidden struct {
+		*base
+		Name string `json:"name"`
+	}
+	b, err = encoder{}.marshal(hidden{base: &base{1, "x"}, Name: "n"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"n"}`, string(b))
+
+	type Named struct {
+		Name  string `json:"name"`
+		Color string
+	}
+	type Tagged struct {
+		Color string `json:"Color"`
+		Seeds int
+	}
+	type Counted struct {
+		Seeds int
+	}
+	type dominance struct {
+		Name string `json:"name"`
+		Named
+		Tagged
+		Counted
+	}
+	d := dominance{
+		Name:    "outer",
+		Named:   Named{"inner", "red"},
+		Tagged:  Tagged{"green", 1},
+		Counted: Counted{2},
+	}
+	b, err = encoder{}.marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"outer","Color":"green"}`, string(b))
+	expected, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(expected), string(b))
+}
@@ -0,0 +1,231 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ExportFormat is an output format of Export
+type ExportFormat uint8
+
+const (
+	// ExportCSV writes comma separated values with a header row
+	ExportCSV ExportFormat = iota
+	// ExportJSONL writes one JSON object per line
+	ExportJSONL
+	// ExportTSV writes tab separated values with a header row, it can be opened in Excel
+	ExportTSV
+)
+
+const defaultExportPageSize = 100
+
+// ExportOptions configures Export
+type ExportOptions struct {
+	Format ExportFormat
+	// Columns are dotted field paths (e.g. lefield.email) in the output order,
+	// all fields of the read model are exported when empty
+	Columns []string
+	// PageSize is a number of items fetched by a single request, defaults to 100
+	PageSize int
+}
+
+// Export pages through items matching the query and writes them to w
+// Nested fields are flattened into columns named by their dotted paths,
+// lists and maps are written as JSON. JSONL without Columns writes items
+// as they are, with Columns it writes objects keyed by the column paths.
+// Limit and offset of the query bound the exported range, use sorting
+// of the query to get a stable order of items.
+func (d API[R, W, PK]) Export(ctx context.Context, w io.Writer, q query, opts ExportOptions) error {
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultExportPageSize
+	}
+	columns := opts.Columns
+	if len(columns) == 0 && opts.Format != ExportJSONL {
+		fields, err := d.jsonFieldsR()
+		if err != nil {
+			return err
+		}
+		columns = fields
+	}
+
+	ew, err := newExportWriter(w, opts.Format, columns)
+	if err != nil {
+		return err
+	}
+
+	offset := 0
+	if q.offset != nil {
+		offset = *q.offset
+	}
+	remaining := -1
+	if q.limit != nil {
+		remaining = *q.limit
+	}
+
+	// pages bypass the cache, so an export doesn't evict hot entries
+	uncached := d
+	uncached.Cache = nil
+	for remaining != 0 {
+		limit := pageSize
+		if remaining > 0 && remaining < limit {
+			limit = remaining
+		}
+		items, err := uncached.Items(ctx, q.Limit(limit).Offset(offset))
+		if err != nil {
+			return fmt.Errorf("read page at offset %d: %w", offset, err)
+		}
+		for _, item := range items {
+			if err := ew.write(item); err != nil {
+				return err
+			}
+		}
+		offset += len(items)
+		if remaining > 0 {
+			remaining -= len(items)
+		}
+		if len(items) < limit {
+			break
+		}
+	}
+	return ew.flush()
+}
+
+type exportWriter struct {
+	format  ExportFormat
+	columns []string
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func newExportWriter(w io.Writer, format ExportFormat, columns []string) (*exportWriter, error) {
+	ew := &exportWriter{
+		format:  format,
+		columns: columns,
+	}
+	switch format {
+	case ExportCSV, ExportTSV:
+		ew.csv = csv.NewWriter(w)
+		if format == ExportTSV {
+			ew.csv.Comma = '\t'
+		}
+		if err := ew.csv.Write(columns); err != nil {
+			return nil, fmt.Errorf("write header: %w", err)
+		}
+	case ExportJSONL:
+		ew.json = json.NewEncoder(w)
+	default:
+		return nil, fmt.Errorf("unknown export format %d", format)
+	}
+	return ew, nil
+}
+
+func (ew *exportWriter) write(item any) error {
+	if ew.format == ExportJSONL && len(ew.columns) == 0 {
+		if err := ew.json.Encode(item); err != nil {
+			return fmt.Errorf("write item: %w", err)
+		}
+		return nil
+	}
+
+	doc, err := toGeneric(item)
+	if err != nil {
+		return err
+	}
+
+	if ew.format == ExportJSONL {
+		row := make(map[string]any, len(ew.columns))
+		for _, c := range ew.columns {
+			row[c] = lookupPath(doc, strings.Split(c, "."))
+		}
+		if err := ew.json.Encode(row); err != nil {
+			return fmt.Errorf("write item: %w", err)
+		}
+		return nil
+	}
+
+	row := make([]string, len(ew.columns))
+	for i, c := range ew.columns {
+		row[i], err = formatCell(lookupPath(doc, strings.Split(c, ".")))
+		if err != nil {
+			return fmt.Errorf("format column %s: %w", c, err)
+		}
+	}
+	if err := ew.csv.Write(row); err != nil {
+		return fmt.Errorf("write item: %w", err)
+	}
+	return nil
+}
+
+func (ew *exportWriter) flush() error {
+	if ew.csv != nil {
+		ew.csv.Flush()
+		return ew.csv.Error()
+	}
+	return nil
+}
+
+// toGeneric converts item to its JSON representation made of maps and slices
+func toGeneric(item any) (any, error) {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("marshal item: %w", err)
+	}
+	return decodeGeneric(b)
+}
+
+// decodeGeneric decodes JSON into maps and slices keeping numbers exact,
+// empty input decodes to nil
+func decodeGeneric(b []byte) (any, error) {
+	if len(b) == 0 {
+		return nil, nil
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unmarshal item: %w", err)
+	}
+	return doc, nil
+}
+
+// lookupPath returns value under the dotted path, lists are traversed element-wise
+func lookupPath(doc any, path []string) any {
+	if len(path) == 0 {
+		return doc
+	}
+	switch v := doc.(type) {
+	case map[string]any:
+		return lookupPath(v[path[0]], path[1:])
+	case []any:
+		out := make([]any, len(v))
+		for i, el := range v {
+			out[i] = lookupPath(el, path)
+		}
+		return out
+	default:
+		return nil
+	}
+}
+
+func formatCell(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		b, err := json.Marshal(v)
+		return string(b), err
+	}
+}
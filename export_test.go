@@ -0,0 +1,68 @@
+package directusapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	fruits := []map[string]any{
+		{"id": 1, "name": "apple", "area": []string{"europe"}, "lefield": map[string]any{"id": 1, "email": "a@example.com"}},
+		{"id": 2, "name": "pear, green", "lefield": map[string]any{"id": 2, "email": "b@example.com"}},
+		{"id": 3, "name": "plum", "lefield": map[string]any{"id": 1, "email": "a@example.com"}},
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		end := offset + limit
+		if end > len(fruits) {
+			end = len(fruits)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": fruits[offset:end]})
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	ctx := context.Background()
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := api.Export(ctx, &buf, None(), ExportOptions{
+			Columns:  []string{"name", "lefield.email", "area"},
+			PageSize: 2,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "name,lefield.email,area\n"+
+			"apple,a@example.com,\"[\"\"europe\"\"]\"\n"+
+			"\"pear, green\",b@example.com,\n"+
+			"plum,a@example.com,\n", buf.String())
+	})
+
+	t.Run("jsonl with limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := api.Export(ctx, &buf, Offset(1).Limit(1), ExportOptions{
+			Format:  ExportJSONL,
+			Columns: []string{"id", "lefield.id"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":2,"lefield.id":2}`+"\n", buf.String())
+	})
+
+	t.Run("bypasses cache", func(t *testing.T) {
+		cache := &ReadCache{TTL: time.Minute}
+		cached := api
+		cached.Cache = cache
+		var buf bytes.Buffer
+		require.NoError(t, cached.Export(ctx, &buf, None(), ExportOptions{PageSize: 2}))
+		assert.Zero(t, cache.backend().(*MemoryCache).ll.Len())
+	})
+}
@@ -0,0 +1,113 @@
+package directusapi
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// CSV is a Directus array field (e.g. checkboxes or tags interface)
+// It's decoded from a JSON array, a comma separated string or null,
+// and encoded as a JSON array, nil CSV is encoded as null.
+type CSV[T any] []T
+
+func (c CSV[T]) MarshalJSON() ([]byte, error) {
+	if c == nil {
+		return []byte(`null`), nil
+	}
+	return json.Marshal([]T(c))
+}
+
+func (c *CSV[T]) UnmarshalJSON(data []byte) error {
+	s := strings.TrimSpace(string(data))
+	switch {
+	case s == "null":
+		*c = nil
+		return nil
+	case strings.HasPrefix(s, "["):
+		var list []T
+		if err := json.Unmarshal(data, &list); err != nil {
+			return err
+		}
+		*c = list
+		return nil
+	}
+
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return fmt.Errorf("decoding csv field: %w", err)
+	}
+	joined = strings.Trim(joined, ",")
+	if joined == "" {
+		*c = CSV[T]{}
+		return nil
+	}
+	parts := strings.Split(joined, ",")
+	list := make(CSV[T], len(parts))
+	for i, part := range parts {
+		if err := unmarshalText(strings.TrimSpace(part), &list[i]); err != nil {
+			return fmt.Errorf("decoding csv field element %q: %w", part, err)
+		}
+	}
+	*c = list
+	return nil
+}
+
+func (c CSV[T]) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
+
+// JSON is a Directus json or key-value field holding T
+// It's decoded from a JSON value, a string with encoded JSON or null,
+// strings are taken as encoded JSON unless T is a string.
+type JSON[T any] struct {
+	Data T
+}
+
+func (j JSON[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(j.Data)
+}
+
+func (j *JSON[T]) UnmarshalJSON(data []byte) error {
+	var zero T
+	j.Data = zero
+	s := strings.TrimSpace(string(data))
+	if s == "null" {
+		return nil
+	}
+	if strings.HasPrefix(s, `"`) && reflect.TypeOf(&j.Data).Elem().Kind() != reflect.String {
+		// value is stored as a string with encoded JSON
+		var encoded string
+		if err := json.Unmarshal(data, &encoded); err != nil {
+			return err
+		}
+		if strings.TrimSpace(encoded) == "" {
+			return nil
+		}
+		data = []byte(encoded)
+	}
+	return json.Unmarshal(data, &j.Data)
+}
+
+func (j JSON[T]) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
+
+// unmarshalText decodes a text element into dest, strings are taken
+// as they are, other values are parsed as JSON
+func unmarshalText(s string, dest any) error {
+	switch v := dest.(type) {
+	case *string:
+		*v = s
+		return nil
+	case encoding.TextUnmarshaler:
+		return v.UnmarshalText([]byte(s))
+	}
+	if reflect.TypeOf(dest).Elem().Kind() == reflect.String {
+		b, _ := json.Marshal(s)
+		return json.Unmarshal(b, dest)
+	}
+	return json.Unmarshal([]byte(s), dest)
+}
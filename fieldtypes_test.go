@@ -0,0 +1,77 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCSV(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected CSV[string]
+	}{
+		{`["europe","africa"]`, CSV[string]{"europe", "africa"}},
+		{`"europe,africa"`, CSV[string]{"europe", "africa"}},
+		{`",europe,africa,"`, CSV[string]{"europe", "africa"}},
+		{`""`, CSV[string]{}},
+		{`null`, nil},
+	}
+	for _, tt := range tests {
+		var c CSV[string]
+		require.NoError(t, json.Unmarshal([]byte(tt.in), &c), tt.in)
+		assert.Equal(t, tt.expected, c, tt.in)
+	}
+
+	var ids CSV[int]
+	require.NoError(t, json.Unmarshal([]byte(`"1,2,3"`), &ids))
+	assert.Equal(t, CSV[int]{1, 2, 3}, ids)
+	assert.Error(t, json.Unmarshal([]byte(`"1,two"`), &ids))
+
+	b, err := json.Marshal(CSV[string]{"europe", "africa"})
+	require.NoError(t, err)
+	assert.Equal(t, `["europe","africa"]`, string(b))
+	b, err = json.Marshal(CSV[string](nil))
+	require.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+}
+
+func TestJSON(t *testing.T) {
+	tests := []string{
+		`{"josef":"10"}`,
+		`"{\"josef\":\"10\"}"`,
+	}
+	for _, in := range tests {
+		var j JSON[map[string]string]
+		require.NoError(t, json.Unmarshal([]byte(in), &j), in)
+		assert.Equal(t, map[string]string{"josef": "10"}, j.Data, in)
+	}
+
+	for _, in := range []string{`null`, `""`} {
+		j := JSON[map[string]string]{Data: map[string]string{"josef": "10"}}
+		require.NoError(t, json.Unmarshal([]byte(in), &j), in)
+		assert.Nil(t, j.Data, in)
+	}
+
+	var s JSON[string]
+	require.NoError(t, json.Unmarshal([]byte(`"plain"`), &s))
+	assert.Equal(t, "plain", s.Data)
+
+	b, err := json.Marshal(JSON[map[string]string]{Data: map[string]string{"josef": "10"}})
+	require.NoError(t, err)
+	assert.Equal(t, `{"josef":"10"}`, string(b))
+}
+
+func TestTypedFieldsAreSingleFields(t *testing.T) {
+	type fruit struct {
+		Area      CSV[string]                     `json:"area"`
+		Favorites JSON[map[string]string]         `json:"favorites"`
+		Nutrition Optional[JSON[struct{ A int }]] `json:"nutrition"`
+	}
+	fields, err := fieldsOf(reflect.TypeOf(fruit{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"area", "favorites", "nutrition"}, fields)
+}
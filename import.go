@@ -0,0 +1,407 @@
+package directusapi
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ImportFormat is an input format of Import
+type ImportFormat uint8
+
+const (
+	// ImportCSV reads comma separated values with a header row of dotted field paths
+	ImportCSV ImportFormat = iota
+	// ImportJSONL reads one JSON object per line
+	ImportJSONL
+)
+
+const defaultImportBatchSize = 100
+
+// ImportOptions configures Import
+type ImportOptions[W any] struct {
+	Format ImportFormat
+	// BatchSize is a number of items written by a single request, defaults to 100
+	BatchSize int
+	// KeyField turns inserts into upserts, items are matched by this unique field
+	KeyField string
+	// DryRun reads and validates all rows without writing anything
+	DryRun bool
+	// Skip is a number of rows to skip, use ImportResult.Rows of an
+	// interrupted import to resume it
+	Skip int
+	// Validate is called for every decoded row, the row is rejected on error
+	Validate func(item W) error
+	// Progress is called after every written batch
+	Progress func(res ImportResult)
+}
+
+// ImportResult is a summary of Import
+type ImportResult struct {
+	// Rows is a number of processed rows including skipped ones
+	Rows    int
+	Created int
+	Updated int
+	Errors  []RowError
+}
+
+// RowError is an error of a single imported row
+type RowError struct {
+	// Row is 1-based index of a data row, CSV header is not counted
+	Row int
+	Err error
+}
+
+func (e RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+func (e RowError) Unwrap() error {
+	return e.Err
+}
+
+// Import reads items of the write model from r and writes them in batches
+// CSV cells are converted to the types of the write model fields, empty cells
+// leave zero values and unset Optional fields, lists, maps and structs are
+// expected as JSON. Rows failing the conversion or validation are reported
+// in the result and the import goes on with the next row.
+func (d API[R, W, PK]) Import(ctx context.Context, r io.Reader, opts ImportOptions[W]) (ImportResult, error) {
+	batchSize := opts.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultImportBatchSize
+	}
+
+	var rr rowReader[W]
+	switch opts.Format {
+	case ImportCSV:
+		rr = &csvRowReader[W]{r: csv.NewReader(r)}
+	case ImportJSONL:
+		rr = &jsonlRowReader[W]{r: bufio.NewReader(r)}
+	default:
+		return ImportResult{}, fmt.Errorf("unknown import format %d", opts.Format)
+	}
+
+	res := ImportResult{}
+	batch := []importRow[W]{}
+	read := 0
+	flush := func() error {
+		if len(batch) > 0 {
+			if err := d.importBatch(ctx, batch, opts, &res); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+		res.Rows = read
+		if opts.Progress != nil {
+			opts.Progress(res)
+		}
+		return nil
+	}
+
+	for {
+		item, err := rr.next(read < opts.Skip)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		read++
+		if read <= opts.Skip {
+			continue
+		}
+		var rowErr rowError
+		if errors.As(err, &rowErr) {
+			res.Errors = append(res.Errors, RowError{read, rowErr.err})
+			continue
+		}
+		if err != nil {
+			return res, fmt.Errorf("read row %d: %w", read, err)
+		}
+		if opts.Validate != nil {
+			if err := opts.Validate(item); err != nil {
+				res.Errors = append(res.Errors, RowError{read, err})
+				continue
+			}
+		}
+
+		batch = append(batch, importRow[W]{read, item})
+		if len(batch) >= batchSize {
+			if err := flush(); err != nil {
+				return res, err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return res, err
+	}
+	return res, nil
+}
+
+type importRow[W any] struct {
+	row  int
+	item W
+}
+
+// importBatch writes the batch, write errors are reported for all rows of the batch
+func (d API[R, W, PK]) importBatch(ctx context.Context, batch []importRow[W], opts ImportOptions[W], res *ImportResult) error {
+	rows := make([]importRow[W], 0, len(batch))
+	items := make([]W, 0, len(batch))
+	keys := make([]string, 0, len(batch))
+	for _, r := range batch {
+		if opts.KeyField != "" {
+			key, err := fieldString(r.item, opts.KeyField)
+			if err != nil {
+				res.Errors = append(res.Errors, RowError{r.row, err})
+				continue
+			}
+			keys = append(keys, key)
+		}
+		rows = append(rows, r)
+		items = append(items, r.item)
+	}
+
+	var err error
+	switch {
+	case len(rows) == 0:
+	case opts.KeyField == "" && opts.DryRun:
+		res.Created += len(rows)
+	case opts.KeyField == "":
+		_, err = d.InsertMany(ctx, items)
+		if err == nil {
+			res.Created += len(rows)
+		}
+	case opts.DryRun:
+		var existing map[string]PK
+		existing, err = d.existingByKey(ctx, opts.KeyField, keys)
+		for _, key := range keys {
+			if _, ok := existing[key]; ok {
+				res.Updated++
+			} else {
+				res.Created++
+			}
+		}
+	default:
+		var upserted []UpsertResult[R]
+		upserted, err = d.UpsertMany(ctx, opts.KeyField, items)
+		for _, u := range upserted {
+			if u.Created {
+				res.Created++
+			} else {
+				res.Updated++
+			}
+		}
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		for _, r := range rows {
+			res.Errors = append(res.Errors, RowError{r.row, err})
+		}
+	}
+	return nil
+}
+
+// fieldString returns string representation of item's field under dotted path
+func fieldString(item any, path string) (string, error) {
+	doc, err := toGeneric(item)
+	if err != nil {
+		return "", err
+	}
+	v := lookupPath(doc, strings.Split(path, "."))
+	if v == nil {
+		return "", fmt.Errorf("field %s is empty", path)
+	}
+	return formatCell(v)
+}
+
+type rowReader[W any] interface {
+	// next returns next decoded row, rows are only consumed when skip is true
+	next(skip bool) (W, error)
+}
+
+// rowError is a recoverable error of a single row
+type rowError struct {
+	err error
+}
+
+func (e rowError) Error() string {
+	return e.err.Error()
+}
+
+type jsonlRowReader[W any] struct {
+	r *bufio.Reader
+}
+
+func (jr *jsonlRowReader[W]) next(skip bool) (W, error) {
+	var item W
+	for {
+		line, err := jr.r.ReadBytes('\n')
+		if err != nil && !(errors.Is(err, io.EOF) && len(line) > 0) {
+			return item, err
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if skip {
+			return item, nil
+		}
+		if err := json.Unmarshal(line, &item); err != nil {
+			return item, rowError{err}
+		}
+		return item, nil
+	}
+}
+
+type csvRowReader[W any] struct {
+	r      *csv.Reader
+	header []string
+}
+
+func (cr *csvRowReader[W]) next(skip bool) (W, error) {
+	var item W
+	if cr.header == nil {
+		header, err := cr.r.Read()
+		if err != nil {
+			return item, err
+		}
+		cr.header = header
+		cr.r.FieldsPerRecord = len(header)
+	}
+
+	record, err := cr.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return item, rowError{err}
+	}
+	if err != nil {
+		return item, err
+	}
+	if skip {
+		return item, nil
+	}
+
+	doc := map[string]any{}
+	t := reflect.TypeOf(item)
+	for i, column := range cr.header {
+		if record[i] == "" && !isOptionalPath(t, column) {
+			continue
+		}
+		v, err := cellValue(t, strings.Split(column, "."), record[i])
+		if err != nil {
+			return item, rowError{fmt.Errorf("column %s: %w", column, err)}
+		}
+		setPath(doc, strings.Split(column, "."), v)
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		return item, rowError{err}
+	}
+	if err := json.Unmarshal(b, &item); err != nil {
+		return item, rowError{err}
+	}
+	return item, nil
+}
+
+// cellValue converts CSV cell into a JSON value of the field under path in t
+func cellValue(t reflect.Type, path []string, s string) (any, error) {
+	if len(path) > 0 {
+		ft, ok := fieldType(t, path[0])
+		if !ok {
+			return nil, fmt.Errorf("unknown field %s", path[0])
+		}
+		return cellValue(ft, path[1:], s)
+	}
+
+	if opt, ok := reflect.New(t).Interface().(isOpt); ok {
+		if s == "" {
+			return nil, nil
+		}
+		return cellValue(opt.elemType(), nil, s)
+	}
+	if reflect.PointerTo(t).Implements(jsonUnmarshalerType) {
+		// types decoding themselves (e.g. Time) get the cell as it is
+		return s, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return s, nil
+	case reflect.Bool:
+		return strconv.ParseBool(s)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(s, 10, t.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(s, 10, t.Bits())
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(s, t.Bits())
+	case reflect.Pointer:
+		if s == "" {
+			return nil, nil
+		}
+		return cellValue(t.Elem(), nil, s)
+	default:
+		if s == "" {
+			return nil, nil
+		}
+		if !json.Valid([]byte(s)) {
+			return nil, fmt.Errorf("invalid JSON %q", s)
+		}
+		return json.RawMessage(s), nil
+	}
+}
+
+// fieldType finds type of the struct field by its JSON name, fields are resolved
+// like selected fields, Optional and pointer of struct are unwrapped
+func fieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	if opt, ok := reflect.New(t).Interface().(isOpt); ok {
+		t = opt.elemType()
+	}
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+	for _, f := range jsonFields(t, isFlattened) {
+		if f.name == name {
+			return f.Type, true
+		}
+	}
+	return nil, false
+}
+
+func isOptionalPath(t reflect.Type, column string) bool {
+	ft, ok := t, true
+	for _, name := range strings.Split(column, ".") {
+		ft, ok = fieldType(ft, name)
+		if !ok {
+			return false
+		}
+	}
+	_, isOptional := reflect.New(ft).Interface().(isOpt)
+	return isOptional
+}
+
+func setPath(doc map[string]any, path []string, v any) {
+	if len(path) == 1 {
+		doc[path[0]] = v
+		return
+	}
+	next, ok := doc[path[0]].(map[string]any)
+	if !ok {
+		next = map[string]any{}
+		doc[path[0]] = next
+	}
+	setPath(next, path[1:], v)
+}
+
+var jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
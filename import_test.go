@@ -0,0 +1,141 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportCSV(t *testing.T) {
+	inserted := [][]map[string]any{}
+	patched := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "apple,pear,plum", r.URL.Query().Get("filter[name][in]"))
+			_, _ = w.Write([]byte(`{"data":[{"id":7,"name":"pear"}]}`))
+		case http.MethodPost:
+			var items []map[string]any
+			b, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(b, &items))
+			inserted = append(inserted, items)
+			data := make([]map[string]any, len(items))
+			for i, item := range items {
+				data[i] = map[string]any{"id": 10 + i, "name": item["name"]}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": data})
+		case http.MethodPatch:
+			patched = append(patched, r.URL.Path)
+			_, _ = w.Write([]byte(`{"data":{}}`))
+		}
+	}))
+	defer srv.Close()
+
+	input := "name,weight,price,discovered_at,area,lefield\n" +
+		"apple,3,,2022-05-05 10:30:00,\"[\"\"europe\"\"]\",1\n" +
+		"pear,x,1.5,,,1\n" +
+		"pear,2,1.5,,,1\n" +
+		"melon,2,1.5,,,1\n" +
+		"plum,1,0.3,,,2\n"
+
+	api := testAPI(srv)
+	progress := []int{}
+	res, err := api.Import(context.Background(), strings.NewReader(input), ImportOptions[FruitW]{
+		KeyField: "name",
+		Validate: func(f FruitW) error {
+			if f.Name == "melon" {
+				return errors.New("melon is not allowed")
+			}
+			return nil
+		},
+		Progress: func(res ImportResult) {
+			progress = append(progress, res.Rows)
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, res.Rows)
+	assert.Equal(t, 2, res.Created)
+	assert.Equal(t, 1, res.Updated)
+	require.Len(t, res.Errors, 2)
+	assert.Equal(t, 2, res.Errors[0].Row)
+	assert.Equal(t, 4, res.Errors[1].Row)
+	assert.Equal(t, []int{5}, progress)
+
+	assert.Equal(t, []string{"/_/items/fruits/7"}, patched)
+	require.Len(t, inserted, 1)
+	require.Len(t, inserted[0], 2)
+	assert.Equal(t, "apple", inserted[0][0]["name"])
+	assert.Equal(t, float64(3), inserted[0][0]["weight"])
+	assert.Nil(t, inserted[0][0]["price"])
+	assert.Equal(t, "2022-05-05 10:30:00", inserted[0][0]["discovered_at"])
+	assert.Equal(t, []any{"europe"}, inserted[0][0]["area"])
+}
+
+func TestImportJSONLDryRunResume(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s %s", r.Method, r.URL)
+	}))
+	defer srv.Close()
+
+	input := `{"name":"apple","discovered_at":"2022-05-05 10:30:00"}
+{"name":"pear","discovered_at":"yesterday"}
+
+{"name":"plum","discovered_at":"2022-05-05 10:30:00"}
+`
+	api := testAPI(srv)
+	names := []string{}
+	res, err := api.Import(context.Background(), strings.NewReader(input), ImportOptions[FruitW]{
+		Format: ImportJSONL,
+		DryRun: true,
+		Skip:   1,
+		Validate: func(f FruitW) error {
+			names = append(names, f.Name)
+			assert.Equal(t, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC), f.DiscoveredAt.Time)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, res.Rows)
+	assert.Equal(t, 1, res.Created)
+	require.Len(t, res.Errors, 1)
+	assert.Equal(t, 2, res.Errors[0].Row)
+	assert.Equal(t, []string{"plum"}, names)
+}
+
+func TestImportEmbeddedFields(t *testing.T) {
+	type Base struct {
+		Code string `json:"code"`
+	}
+	type item struct {
+		Base
+		Name   string `json:"name"`
+		Secret string `json:"-"`
+	}
+	api := API[item, item, int]{CollectionName: "items"}
+	var imported []item
+	res, err := api.Import(context.Background(), strings.NewReader("code,name\nA,b\n"), ImportOptions[item]{
+		DryRun: true,
+		Validate: func(it item) error {
+			imported = append(imported, it)
+			return nil
+		},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.Errors)
+	assert.Equal(t, []item{{Base: Base{"A"}, Name: "b"}}, imported)
+
+	res, err = api.Import(context.Background(), strings.NewReader("-\nx\n"), ImportOptions[item]{DryRun: true})
+	require.NoError(t, err)
+	require.Len(t, res.Errors, 1)
+	assert.Contains(t, res.Errors[0].Error(), "unknown field")
+}
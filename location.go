@@ -0,0 +1,53 @@
+package directusapi
+
+import (
+	"reflect"
+	"time"
+)
+
+// localReader is implemented by types reinterpreting decoded values in the server's location
+type localReader interface {
+	readIn(loc *time.Location)
+}
+
+// readIn reinterprets all datetimes of decoded dest in the location, dest has to be a pointer
+func readIn(dest any, loc *time.Location) {
+	if loc == nil || dest == nil {
+		return
+	}
+	readValueIn(reflect.ValueOf(dest), loc)
+}
+
+func readValueIn(v reflect.Value, loc *time.Location) {
+	if v.CanAddr() {
+		if lr, ok := v.Addr().Interface().(localReader); ok {
+			lr.readIn(loc)
+			return
+		}
+	}
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			readValueIn(v.Elem(), loc)
+		}
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				readValueIn(v.Field(i), loc)
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			readValueIn(v.Index(i), loc)
+		}
+	case reflect.Map:
+		iter := v.MapRange()
+		for iter.Next() {
+			// map values aren't addressable, a copy is updated and stored back
+			el := reflect.New(iter.Value().Type()).Elem()
+			el.Set(iter.Value())
+			readValueIn(el, loc)
+			v.SetMapIndex(iter.Key(), el)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultMigrationsCollection = "schema_migrations"
+
+// Migration is a versioned schema change of a project
+// Migrations are written in Go or loaded from JSON files by LoadMigrations.
+type Migration struct {
+	Version int
+	Name    string
+	Up      func(ctx context.Context, c Dynamic) error
+	Down    func(ctx context.Context, c Dynamic) error
+}
+
+// MigrationStatus tells whether the migration was applied
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	Applied   bool
+	AppliedOn Time
+}
+
+// Migrator applies migrations and records applied versions in a collection
+type Migrator struct {
+	Client     Dynamic
+	Migrations []Migration
+	// Collection records applied versions, defaults to "schema_migrations"
+	Collection string
+}
+
+// MigrationOp is a single step of a JSON migration, exactly one field is set
+type MigrationOp struct {
+	CreateCollection Record       `json:"create_collection,omitempty"`
+	DropCollection   string       `json:"drop_collection,omitempty"`
+	AddField         *FieldOp     `json:"add_field,omitempty"`
+	AlterField       *FieldOp     `json:"alter_field,omitempty"`
+	RemoveField      *FieldOp     `json:"remove_field,omitempty"`
+	AddRelation      Record       `json:"add_relation,omitempty"`
+	RemoveRelation   *RelationOp  `json:"remove_relation,omitempty"`
+	SeedItems        *SeedItemsOp `json:"seed_items,omitempty"`
+	DeleteItems      *SeedItemsOp `json:"delete_items,omitempty"`
+}
+
+// FieldOp adds, alters or removes a field
+// Definition is the field for add_field and the changes for alter_field.
+type FieldOp struct {
+	Collection string `json:"collection"`
+	Field      string `json:"field,omitempty"`
+	Definition Record `json:"definition,omitempty"`
+}
+
+// RelationOp identifies a relation by its many side field
+type RelationOp struct {
+	CollectionMany string `json:"collection_many"`
+	FieldMany      string `json:"field_many"`
+}
+
+// SeedItemsOp inserts items, or deletes items by their primary keys in IDs
+type SeedItemsOp struct {
+	Collection string   `json:"collection"`
+	Items      []Record `json:"items,omitempty"`
+	IDs        []string `json:"ids,omitempty"`
+}
+
+// migrationFile is a JSON migration named <version>_<name>.json
+type migrationFile struct {
+	Up   []MigrationOp `json:"up"`
+	Down []MigrationOp `json:"down"`
+}
+
+// LoadMigrations reads JSON migrations named <version>_<name>.json from fsys
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Migration, 0, len(names))
+	for _, name := range names {
+		base := strings.TrimSuffix(path.Base(name), ".json")
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 {
+			return nil, fmt.Errorf("migration %s: name has to be <version>_<name>.json", name)
+		}
+		b, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		var f migrationFile
+		if err := json.Unmarshal(b, &f); err != nil {
+			return nil, fmt.Errorf("migration %s: %w", name, err)
+		}
+		up, down := f.Up, f.Down
+		out = append(out, Migration{
+			Version: version,
+			Name:    parts[1],
+			Up: func(ctx context.Context, c Dynamic) error {
+				return applyOps(ctx, c, up)
+			},
+			Down: func(ctx context.Context, c Dynamic) error {
+				return applyOps(ctx, c, down)
+			},
+		})
+	}
+	return out, nil
+}
+
+func applyOps(ctx context.Context, c Dynamic, ops []MigrationOp) error {
+	for i, op := range ops {
+		if err := op.apply(ctx, c); err != nil {
+			return fmt.Errorf("step %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+func (op MigrationOp) apply(ctx context.Context, c Dynamic) error {
+	switch {
+	case op.CreateCollection != nil:
+		return c.CreateCollection(ctx, op.CreateCollection)
+	case op.DropCollection != "":
+		return c.DeleteCollection(ctx, op.DropCollection)
+	case op.AddField != nil:
+		return c.CreateField(ctx, op.AddField.Collection, op.AddField.Definition)
+	case op.AlterField != nil:
+		return c.UpdateField(ctx, op.AlterField.Collection, op.AlterField.Field, op.AlterField.Definition)
+	case op.RemoveField != nil:
+		return c.DeleteField(ctx, op.RemoveField.Collection, op.RemoveField.Field)
+	case op.AddRelation != nil:
+		return c.CreateRelation(ctx, op.AddRelation)
+	case op.RemoveRelation != nil:
+		return c.DeleteRelation(ctx, op.RemoveRelation.CollectionMany, op.RemoveRelation.FieldMany)
+	case op.SeedItems != nil:
+		_, err := c.Collection(op.SeedItems.Collection).InsertMany(ctx, op.SeedItems.Items)
+		return err
+	case op.DeleteItems != nil:
+		return c.Collection(op.DeleteItems.Collection).DeleteMany(ctx, op.DeleteItems.IDs)
+	default:
+		return fmt.Errorf("empty migration step")
+	}
+}
+
+// Up applies pending migrations in the order of versions, steps limits
+// the number of applied migrations, 0 applies all of them. The versions
+// collection is created when it doesn't exist.
+func (m Migrator) Up(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, err := m.sorted()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for _, mig := range migrations {
+		if steps > 0 && len(done) == steps {
+			break
+		}
+		if _, ok := applied[mig.Version]; ok {
+			continue
+		}
+		if mig.Up == nil {
+			return done, fmt.Errorf("migration %d_%s has no up step", mig.Version, mig.Name)
+		}
+		if err := mig.Up(ctx, m.Client); err != nil {
+			return done, fmt.Errorf("migration %d_%s up: %w", mig.Version, mig.Name, err)
+		}
+		_, err := m.versions().Insert(ctx, Record{
+			"version":    mig.Version,
+			"name":       mig.Name,
+			"applied_on": Time{time.Now().UTC()},
+		})
+		if err != nil {
+			return done, fmt.Errorf("record migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Down reverts applied migrations from the latest version, steps limits
+// the number of reverted migrations, 0 reverts all of them
+func (m Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	migrations, err := m.sorted()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	done := []Migration{}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		mig := migrations[i]
+		if steps > 0 && len(done) == steps {
+			break
+		}
+		rec, ok := applied[mig.Version]
+		if !ok {
+			continue
+		}
+		if mig.Down == nil {
+			return done, fmt.Errorf("migration %d_%s has no down step", mig.Version, mig.Name)
+		}
+		if err := mig.Down(ctx, m.Client); err != nil {
+			return done, fmt.Errorf("migration %d_%s down: %w", mig.Version, mig.Name, err)
+		}
+		if err := m.versions().Delete(ctx, idString(rec["id"])); err != nil {
+			return done, fmt.Errorf("unrecord migration %d_%s: %w", mig.Version, mig.Name, err)
+		}
+		done = append(done, mig)
+	}
+	return done, nil
+}
+
+// Status lists all migrations in the order of versions
+func (m Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := m.sorted()
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.applied(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]MigrationStatus, len(migrations))
+	for i, mig := range migrations {
+		out[i] = MigrationStatus{
+			Version: mig.Version,
+			Name:    mig.Name,
+		}
+		if rec, ok := applied[mig.Version]; ok {
+			out[i].Applied = true
+			out[i].AppliedOn, _ = rec.TimeIn("applied_on", m.Client.Location)
+		}
+	}
+	return out, nil
+}
+
+func (m Migrator) collection() string {
+	if m.Collection == "" {
+		return defaultMigrationsCollection
+	}
+	return m.Collection
+}
+
+func (m Migrator) versions() API[Record, Record, string] {
+	return m.Client.Collection(m.collection())
+}
+
+func (m Migrator) sorted() ([]Migration, error) {
+	migrations := append([]Migration{}, m.Migrations...)
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version == migrations[i-1].Version {
+			return nil, fmt.Errorf("duplicate migration version %d", migrations[i].Version)
+		}
+	}
+	return migrations, nil
+}
+
+// applied reads records of applied migrations keyed by version, a missing
+// versions collection means no migrations are applied, it's created when create is set
+func (m Migrator) applied(ctx context.Context, create bool) (map[int]Record, error) {
+	exists, err := m.Client.CollectionExists(ctx, m.collection())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		if !create {
+			return map[int]Record{}, nil
+		}
+		if err := m.Client.CreateCollection(ctx, migrationsCollection(m.collection())); err != nil {
+			return nil, fmt.Errorf("create migrations collection: %w", err)
+		}
+	}
+
+	records, err := m.versions().Items(ctx, Limit(-1))
+	if err != nil {
+		return nil, fmt.Errorf("read applied migrations: %w", err)
+	}
+	out := make(map[int]Record, len(records))
+	for _, rec := range records {
+		version, ok := rec.Int("version")
+		if !ok {
+			return nil, fmt.Errorf("invalid migration record %v", rec)
+		}
+		out[int(version)] = rec
+	}
+	return out, nil
+}
+
+// migrationsCollection is a definition of the collection recording applied versions
+func migrationsCollection(name string) Record {
+	return Record{
+		"collection": name,
+		"hidden":     true,
+		"fields": []Record{
+			{
+				"field":          "id",
+				"type":           "integer",
+				"datatype":       "INT",
+				"length":         10,
+				"interface":      "primary-key",
+				"primary_key":    true,
+				"auto_increment": true,
+				"signed":         false,
+			},
+			{
+				"field":     "version",
+				"type":      "integer",
+				"datatype":  "INT",
+				"length":    10,
+				"interface": "numeric",
+				"unique":    true,
+			},
+			{
+				"field":     "name",
+				"type":      "string",
+				"datatype":  "VARCHAR",
+				"length":    255,
+				"interface": "text-input",
+			},
+			{
+				"field":     "applied_on",
+				"type":      "datetime",
+				"datatype":  "DATETIME",
+				"interface": "datetime",
+			},
+		},
+	}
+}
@@ -0,0 +1,153 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrator(t *testing.T) {
+	var mu sync.Mutex
+	collections := map[string]bool{}
+	versions := map[string]Record{}
+	calls := []string{}
+	nextID := 1
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		path := strings.TrimPrefix(r.URL.Path, "/_/")
+		var body Record
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+		}
+		switch {
+		case r.Method == http.MethodGet && strings.HasPrefix(path, "collections/"):
+			if !collections[strings.TrimPrefix(path, "collections/")] {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":200,"message":"Collection not found"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":{}}`)
+		case r.Method == http.MethodPost && path == "collections":
+			name, _ := body.String("collection")
+			collections[name] = true
+			calls = append(calls, "create "+name)
+			fmt.Fprint(w, `{"data":{}}`)
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "collections/"):
+			name := strings.TrimPrefix(path, "collections/")
+			delete(collections, name)
+			calls = append(calls, "drop "+name)
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPost && strings.HasPrefix(path, "fields/"):
+			field, _ := body.String("field")
+			calls = append(calls, "field "+strings.TrimPrefix(path, "fields/")+"."+field)
+			fmt.Fprint(w, `{"data":{}}`)
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "fields/"):
+			calls = append(calls, "remove "+strings.Replace(strings.TrimPrefix(path, "fields/"), "/", ".", 1))
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodGet && path == "items/schema_migrations":
+			list := []Record{}
+			for _, rec := range versions {
+				list = append(list, rec)
+			}
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": list})
+		case r.Method == http.MethodPost && path == "items/schema_migrations":
+			body["id"] = nextID
+			versions[fmt.Sprint(nextID)] = body
+			nextID++
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": body})
+		case r.Method == http.MethodDelete && strings.HasPrefix(path, "items/schema_migrations/"):
+			delete(versions, strings.TrimPrefix(path, "items/schema_migrations/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	migrations, err := LoadMigrations(fstest.MapFS{
+		"001_fruits.json": {Data: []byte(`{
+			"up": [{"create_collection": {"collection": "fruits", "fields": []}}],
+			"down": [{"drop_collection": "fruits"}]
+		}`)},
+		"002_fruit_color.json": {Data: []byte(`{
+			"up": [{"add_field": {"collection": "fruits", "definition": {"field": "color", "type": "string"}}}],
+			"down": [{"remove_field": {"collection": "fruits", "field": "color"}}]
+		}`)},
+		"README.md": {Data: []byte("not a migration")},
+	})
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+
+	u, _ := url.Parse(srv.URL)
+	m := Migrator{
+		Client: Dynamic{
+			Scheme:     u.Scheme,
+			Host:       u.Host,
+			Namespace:  "_",
+			HTTPClient: srv.Client(),
+		},
+		Migrations: append(migrations, Migration{
+			Version: 3,
+			Name:    "noop",
+			Up:      func(ctx context.Context, c Dynamic) error { return nil },
+			Down:    func(ctx context.Context, c Dynamic) error { return nil },
+		}),
+	}
+	ctx := context.Background()
+
+	// reading status and reverting don't create the versions collection
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 3)
+	assert.False(t, status[0].Applied)
+	done, err := m.Down(ctx, 0)
+	require.NoError(t, err)
+	assert.Empty(t, done)
+	assert.Empty(t, calls)
+
+	done, err = m.Up(ctx, 2)
+	require.NoError(t, err)
+	assert.Len(t, done, 2)
+	assert.Equal(t, []string{"create schema_migrations", "create fruits", "field fruits.color"}, calls)
+
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, status, 3)
+	assert.True(t, status[0].Applied)
+	assert.Equal(t, "fruit_color", status[1].Name)
+	assert.True(t, status[1].Applied)
+	assert.False(t, status[1].AppliedOn.IsZero())
+	assert.False(t, status[2].Applied)
+
+	done, err = m.Up(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, done, 1)
+	assert.Equal(t, 3, done[0].Version)
+
+	calls = nil
+	done, err = m.Down(ctx, 3)
+	require.NoError(t, err)
+	assert.Len(t, done, 3)
+	assert.Equal(t, []string{"remove fruits.color", "drop fruits"}, calls)
+	assert.Empty(t, versions)
+}
+
+func TestLoadMigrationsInvalidName(t *testing.T) {
+	_, err := LoadMigrations(fstest.MapFS{
+		"fruits.json": {Data: []byte(`{}`)},
+	})
+	assert.Error(t, err)
+}
@@ -1,8 +1,14 @@
 package directusapi
 
 import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"fmt"
 	"reflect"
+	"strconv"
+	"time"
 )
 
 type Optional[T any] struct {
@@ -42,6 +48,45 @@ func (o Optional[T]) IsSet() bool {
 	return o.op == set
 }
 
+// IsZero reports whether the value is untouched (noop), such fields are omitted
+// from request bodies and by encoding/json with omitzero option
+func (o Optional[T]) IsZero() bool {
+	return o.op == noop
+}
+
+// FromPtr creates Optional from a pointer, nil pointer is unset
+func FromPtr[T any](p *T) Optional[T] {
+	if p == nil {
+		return UnsetOptional[T]()
+	}
+	return SetOptional(*p)
+}
+
+// MapOptional converts the value of a set Optional, the operation is kept
+func MapOptional[T, U any](o Optional[T], fn func(T) U) Optional[U] {
+	if o.op != set {
+		return Optional[U]{op: o.op}
+	}
+	return SetOptional(fn(o.value))
+}
+
+// Ptr returns pointer to a copy of the value, nil when the value is not set
+func (o Optional[T]) Ptr() *T {
+	if o.op != set {
+		return nil
+	}
+	v := o.value
+	return &v
+}
+
+// OrElse returns the value or def when the value is not set
+func (o Optional[T]) OrElse(def T) T {
+	if o.op != set {
+		return def
+	}
+	return o.value
+}
+
 func (o Optional[T]) MarshalJSON() ([]byte, error) {
 	switch o.op {
 	case set:
@@ -50,6 +95,7 @@ func (o Optional[T]) MarshalJSON() ([]byte, error) {
 		return []byte(`null`), nil
 	default:
 		// https://github.com/golang/go/issues/11939
+		// API request bodies omit noop fields, see encoder
 		return json.Marshal(nil)
 	}
 }
@@ -69,28 +115,194 @@ func (o *Optional[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// MarshalText formats the value, unset and noop values are an empty text
+// Values other than strings are formatted as JSON.
+func (o Optional[T]) MarshalText() ([]byte, error) {
+	if o.op != set {
+		return []byte{}, nil
+	}
+	switch v := any(o.value).(type) {
+	case encoding.TextMarshaler:
+		return v.MarshalText()
+	case string:
+		return []byte(v), nil
+	default:
+		return json.Marshal(o.value)
+	}
+}
+
+// UnmarshalText parses the value, an empty text is unset
+// Values other than strings are parsed as JSON.
+func (o *Optional[T]) UnmarshalText(data []byte) error {
+	var zeroval T
+	o.value = zeroval
+	if len(data) == 0 {
+		o.op = unset
+		return nil
+	}
+	switch v := any(&o.value).(type) {
+	case encoding.TextUnmarshaler:
+		if err := v.UnmarshalText(data); err != nil {
+			return err
+		}
+	case *string:
+		*v = string(data)
+	default:
+		if err := json.Unmarshal(data, &o.value); err != nil {
+			return err
+		}
+	}
+	o.op = set
+	return nil
+}
+
+// Scan implements sql.Scanner, NULL is unset
+func (o *Optional[T]) Scan(src any) error {
+	var zeroval T
+	o.value = zeroval
+	if src == nil {
+		o.op = unset
+		return nil
+	}
+	if sc, ok := any(&o.value).(sql.Scanner); ok {
+		if err := sc.Scan(src); err != nil {
+			return err
+		}
+		o.op = set
+		return nil
+	}
+	if err := assignScanned(reflect.ValueOf(&o.value).Elem(), src); err != nil {
+		return err
+	}
+	o.op = set
+	return nil
+}
+
+// Value implements driver.Valuer, unset and noop values are NULL
+func (o Optional[T]) Value() (driver.Value, error) {
+	if o.op != set {
+		return nil, nil
+	}
+	if v, ok := any(o.value).(driver.Valuer); ok {
+		return v.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(o.value)
+}
+
+// String returns the value, <unset> or <noop>
+func (o Optional[T]) String() string {
+	switch o.op {
+	case set:
+		return fmt.Sprint(o.value)
+	case unset:
+		return "<unset>"
+	default:
+		return "<noop>"
+	}
+}
+
+// GoString shows the operation and the value, e.g. Optional[int]{set: 3}
+func (o Optional[T]) GoString() string {
+	t := reflect.TypeOf(&o.value).Elem()
+	switch o.op {
+	case set:
+		return fmt.Sprintf("Optional[%s]{set: %#v}", t, o.value)
+	case unset:
+		return fmt.Sprintf("Optional[%s]{unset}", t)
+	default:
+		return fmt.Sprintf("Optional[%s]{noop}", t)
+	}
+}
+
+// assignScanned stores database value src into dst converting
+// numbers, bytes and strings like database/sql does
+func assignScanned(dst reflect.Value, src any) error {
+	sv := reflect.ValueOf(src)
+	switch {
+	case sv.Type().AssignableTo(dst.Type()):
+		dst.Set(sv)
+		return nil
+	case dst.Kind() == reflect.String:
+		switch v := src.(type) {
+		case []byte:
+			dst.SetString(string(v))
+		default:
+			dst.SetString(fmt.Sprint(v))
+		}
+		return nil
+	}
+
+	s := ""
+	switch v := src.(type) {
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		if sv.Type().ConvertibleTo(dst.Type()) && sv.Kind() != reflect.String && dst.Kind() != reflect.String {
+			dst.Set(sv.Convert(dst.Type()))
+			return nil
+		}
+		return fmt.Errorf("scan %T into %s", src, dst.Type())
+	}
+
+	switch dst.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, dst.Type().Bits())
+		if err != nil {
+			return err
+		}
+		dst.SetFloat(n)
+	default:
+		return fmt.Errorf("scan %T into %s", src, dst.Type())
+	}
+	return nil
+}
+
 func (o Optional[T]) getOp() operation {
 	// this is hack for reflection pkg
 	return o.op
 }
 
-func (o Optional[T]) fields(prefix string) []string {
-	var optVal T
-	f := reflect.TypeOf(optVal)
+func (o Optional[T]) fields(prefix string) ([]string, error) {
+	f := o.elemType()
+	if _, isOptional := reflect.New(f).Interface().(isOpt); isOptional {
+		return nil, fmt.Errorf("%s: optional of optional is not supported", prefix)
+	}
+	return typeFields(f, prefix)
+}
 
-	if f.Kind() == reflect.Struct {
-		var t Time
-		isTime := f.ConvertibleTo(reflect.TypeOf(t))
-		isOptional := f.Implements(reflect.TypeOf(new(isOpt)).Elem())
-		if isOptional {
-			panic("optional of optional is not supported")
-		}
-		if isTime {
-			return []string{prefix}
-		}
-		return iterateFields(f, prefix)
+func (o *Optional[T]) readIn(loc *time.Location) {
+	readValueIn(reflect.ValueOf(&o.value).Elem(), loc)
+}
+
+func (o Optional[T]) encodeBody(e encoder) (any, error) {
+	if o.op != set {
+		return nil, nil
 	}
-	return []string{prefix}
+	return e.encode(reflect.ValueOf(&o.value).Elem())
+}
+
+func (o Optional[T]) elemType() reflect.Type {
+	return reflect.TypeOf(&o.value).Elem()
 }
 
 // 1. don't touch the value
@@ -0,0 +1,99 @@
+package directusapi
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOptionalPtr(t *testing.T) {
+	n := 3
+	o := FromPtr(&n)
+	assert.True(t, o.IsSet())
+	n = 4
+	assert.Equal(t, 3, *o.Ptr())
+
+	assert.Nil(t, FromPtr[int](nil).Ptr())
+	assert.False(t, FromPtr[int](nil).IsSet())
+	assert.Nil(t, Optional[int]{}.Ptr())
+}
+
+func TestOptionalMapAndOrElse(t *testing.T) {
+	s := MapOptional(SetOptional(3), strconv.Itoa)
+	assert.Equal(t, "3", s.ValueMust())
+	assert.Equal(t, UnsetOptional[string](), MapOptional(UnsetOptional[int](), strconv.Itoa))
+	assert.Equal(t, Optional[string]{}, MapOptional(Optional[int]{}, strconv.Itoa))
+
+	assert.Equal(t, 3, SetOptional(3).OrElse(5))
+	assert.Equal(t, 5, UnsetOptional[int]().OrElse(5))
+}
+
+func TestOptionalSQL(t *testing.T) {
+	var n Optional[int]
+	require.NoError(t, n.Scan(int64(7)))
+	assert.Equal(t, SetOptional(7), n)
+	require.NoError(t, n.Scan([]byte("8")))
+	assert.Equal(t, SetOptional(8), n)
+	require.NoError(t, n.Scan(nil))
+	assert.Equal(t, UnsetOptional[int](), n)
+	assert.Error(t, n.Scan("eight"))
+
+	var s Optional[string]
+	require.NoError(t, s.Scan([]byte("apple")))
+	assert.Equal(t, SetOptional("apple"), s)
+
+	var ts Optional[Time]
+	require.NoError(t, ts.Scan("2022-05-05 10:30:00"))
+	assert.Equal(t, 2022, ts.ValueMust().Year())
+
+	v, err := SetOptional(int32(3)).Value()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), v)
+	v, err = Optional[int]{}.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+	v, err = SetOptional(Time{time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)}).Value()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC), v)
+}
+
+func TestOptionalText(t *testing.T) {
+	b, err := SetOptional(1.5).MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", string(b))
+	b, err = UnsetOptional[float64]().MarshalText()
+	require.NoError(t, err)
+	assert.Empty(t, b)
+
+	var f Optional[float64]
+	require.NoError(t, f.UnmarshalText([]byte("2.5")))
+	assert.Equal(t, SetOptional(2.5), f)
+	require.NoError(t, f.UnmarshalText(nil))
+	assert.Equal(t, UnsetOptional[float64](), f)
+
+	var s Optional[string]
+	require.NoError(t, s.UnmarshalText([]byte("apple")))
+	assert.Equal(t, SetOptional("apple"), s)
+
+	area := SetOptional([]string{"a", "b"})
+	b, err = area.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, `["a","b"]`, string(b))
+	var decoded Optional[[]string]
+	require.NoError(t, decoded.UnmarshalText(b))
+	assert.Equal(t, area, decoded)
+}
+
+func TestOptionalString(t *testing.T) {
+	assert.Equal(t, "3", SetOptional(3).String())
+	assert.Equal(t, "<unset>", UnsetOptional[int]().String())
+	assert.Equal(t, "<noop>", Optional[int]{}.String())
+
+	assert.Equal(t, `Optional[string]{set: "apple"}`, fmt.Sprintf("%#v", SetOptional("apple")))
+	assert.Equal(t, "Optional[int]{unset}", fmt.Sprintf("%#v", UnsetOptional[int]()))
+	assert.Equal(t, "Optional[int]{noop}", fmt.Sprintf("%#v", Optional[int]{}))
+}
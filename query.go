@@ -1,7 +1,9 @@
 package directusapi
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -14,6 +16,11 @@ type query struct {
 	limit       *int
 	offset      *int
 	searchStr   *string
+	fields      []string
+	depth       *int
+	aliases     map[string]string
+	status      []string
+	lang        *string
 }
 
 func None() query {
@@ -26,6 +33,11 @@ func None() query {
 		nil,
 		nil,
 		nil,
+		nil,
+		nil,
+		map[string]string{},
+		nil,
+		nil,
 	}
 }
 
@@ -109,6 +121,138 @@ func Search(str string) query {
 	return None().Search(str)
 }
 
+// Fields overrides fields reflected from the read model, wildcards
+// like "*", "*.*" or "lefield.*" are passed to Directus as they are
+func (q query) Fields(fields ...string) query {
+	q.fields = fields
+	return q
+}
+
+func Fields(fields ...string) query {
+	return None().Fields(fields...)
+}
+
+// Depth limits nesting of the fields reflected from the read model,
+// Depth(0) requests relations just as their IDs
+func (q query) Depth(depth int) query {
+	q.depth = &depth
+	return q
+}
+
+func Depth(depth int) query {
+	return None().Depth(depth)
+}
+
+// Alias projects a nested field under the dotted path to a top-level field
+// named alias, so it can be decoded into a flat read model
+func (q query) Alias(alias, path string) query {
+	q.aliases[alias] = path
+	return q
+}
+
+func Alias(alias, path string) query {
+	return None().Alias(alias, path)
+}
+
+// Status filters items by their status, "*" returns items of all statuses
+// including soft deleted ones
+func (q query) Status(statuses ...string) query {
+	q.status = statuses
+	return q
+}
+
+func Status(statuses ...string) query {
+	return None().Status(statuses...)
+}
+
+// Lang requests translations in the language with given code
+func (q query) Lang(code string) query {
+	q.lang = &code
+	return q
+}
+
+func Lang(code string) query {
+	return None().Lang(code)
+}
+
+// selectFields returns value of the fields parameter for the reflected fields
+func (q query) selectFields(reflected []string) string {
+	fields := q.fields
+	if len(fields) == 0 {
+		fields = reflected
+		if q.depth != nil {
+			fields = limitDepth(fields, *q.depth)
+		}
+	}
+	if len(q.aliases) == 0 {
+		return strings.Join(fields, ",")
+	}
+
+	// alias names are projected on the client, they aren't server fields
+	selected := make([]string, 0, len(fields)+len(q.aliases))
+	for _, f := range fields {
+		name, _, _ := strings.Cut(f, ".")
+		if _, ok := q.aliases[name]; !ok {
+			selected = append(selected, f)
+		}
+	}
+	fields = selected
+	aliases := make([]string, 0, len(q.aliases))
+	for alias := range q.aliases {
+		aliases = append(aliases, alias)
+	}
+	sort.Strings(aliases)
+	for _, alias := range aliases {
+		fields = appendUnique(fields, q.aliases[alias])
+	}
+	return strings.Join(fields, ",")
+}
+
+// project decodes raw item into dest with aliases of the query applied
+func (q query) project(raw json.RawMessage, dest any) error {
+	if len(q.aliases) == 0 {
+		return json.Unmarshal(raw, dest)
+	}
+	doc, err := decodeGeneric(raw)
+	if err != nil {
+		return err
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return fmt.Errorf("item is not an object")
+	}
+	for alias, path := range q.aliases {
+		obj[alias] = lookupPath(obj, strings.Split(path, "."))
+	}
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, dest)
+}
+
+// limitDepth cuts fields nested deeper than depth, e.g. lefield.id is lefield for depth 0
+func limitDepth(fields []string, depth int) []string {
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		parts := strings.Split(f, ".")
+		if len(parts) > depth+1 {
+			parts = parts[:depth+1]
+		}
+		out = appendUnique(out, strings.Join(parts, "."))
+	}
+	return out
+}
+
+func appendUnique(fields []string, field string) []string {
+	for _, f := range fields {
+		if f == field {
+			return fields
+		}
+	}
+	return append(fields[:len(fields):len(fields)], field)
+}
+
 func (q query) asKeyValue() map[string]string {
 	out := map[string]string{}
 	for k, v := range q.eqFilter {
@@ -132,5 +276,11 @@ func (q query) asKeyValue() map[string]string {
 	if q.offset != nil {
 		out["offset"] = fmt.Sprint(*q.offset)
 	}
+	if len(q.status) > 0 {
+		out["status"] = strings.Join(q.status, ",")
+	}
+	if q.lang != nil {
+		out["lang"] = *q.lang
+	}
 	return out
 }
@@ -0,0 +1,220 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+)
+
+// RelationUpdate describes changes of a one-to-many or many-to-many relation field
+// Related items are identified by their primary keys.
+type RelationUpdate struct {
+	field      string
+	junction   string
+	foreignKey string
+	primaryKey string
+	junctionPK string
+	add        []any
+	remove     []any
+	replace    []any
+	hasReplace bool
+}
+
+// Relations starts an update of the one-to-many relation field
+func Relations(field string) RelationUpdate {
+	return RelationUpdate{field: field}
+}
+
+// Via makes the relation many-to-many, junctionField is a field of
+// the junction collection referencing the related item
+func (r RelationUpdate) Via(junctionField string) RelationUpdate {
+	r.junction = junctionField
+	return r
+}
+
+// ForeignKey names the field of the related collection referencing the item,
+// it's required to detach one-to-many items, which are detached by setting
+// it to null
+func (r RelationUpdate) ForeignKey(field string) RelationUpdate {
+	r.foreignKey = field
+	return r
+}
+
+// PrimaryKey names the primary key field of related items, defaults to "id"
+func (r RelationUpdate) PrimaryKey(field string) RelationUpdate {
+	r.primaryKey = field
+	return r
+}
+
+// JunctionPrimaryKey names the primary key field of M2M junction rows, defaults to "id"
+func (r RelationUpdate) JunctionPrimaryKey(field string) RelationUpdate {
+	r.junctionPK = field
+	return r
+}
+
+func (r RelationUpdate) relatedPK() string {
+	if r.primaryKey == "" {
+		return "id"
+	}
+	return r.primaryKey
+}
+
+func (r RelationUpdate) rowPK() string {
+	if r.junctionPK == "" {
+		return "id"
+	}
+	return r.junctionPK
+}
+
+// Add attaches related items
+func (r RelationUpdate) Add(ids ...any) RelationUpdate {
+	r.add = append(r.add[:len(r.add):len(r.add)], ids...)
+	return r
+}
+
+// Remove detaches related items, the items themselves are kept
+// One-to-many relations need ForeignKey, M2M junction rows are deleted.
+func (r RelationUpdate) Remove(ids ...any) RelationUpdate {
+	r.remove = append(r.remove[:len(r.remove):len(r.remove)], ids...)
+	return r
+}
+
+// Replace makes ids the only related items
+func (r RelationUpdate) Replace(ids ...any) RelationUpdate {
+	r.replace = append([]any{}, ids...)
+	r.hasReplace = true
+	return r
+}
+
+// needsCurrent reports whether the payload depends on currently related items
+func (r RelationUpdate) needsCurrent() bool {
+	return r.hasReplace || (r.junction != "" && len(r.remove) > 0)
+}
+
+// currentFields returns fields needed to read currently related items
+func (r RelationUpdate) currentFields() []string {
+	if r.junction == "" {
+		return []string{r.field + "." + r.relatedPK()}
+	}
+	return []string{r.field + "." + r.rowPK(), r.field + "." + r.junction}
+}
+
+// relatedRow is a currently related item, for M2M id is a junction row
+// and related is the referenced item
+type relatedRow struct {
+	id      any
+	related any
+}
+
+// payload builds nested value of the relation field
+// O2M items are attached by their id and detached by setting their foreign key
+// to null, M2M junction rows are created for added items and deleted for removed ones.
+func (r RelationUpdate) payload(current []relatedRow) ([]map[string]any, error) {
+	add, remove := r.add, r.remove
+	if r.hasReplace {
+		wanted := map[string]bool{}
+		for _, id := range r.replace {
+			wanted[idString(id)] = true
+		}
+		present := map[string]bool{}
+		for _, row := range current {
+			present[idString(row.related)] = true
+			if !wanted[idString(row.related)] {
+				remove = append(remove[:len(remove):len(remove)], row.related)
+			}
+		}
+		for _, id := range r.replace {
+			if !present[idString(id)] {
+				add = append(add[:len(add):len(add)], id)
+			}
+		}
+	}
+
+	out := []map[string]any{}
+	for _, id := range add {
+		if r.junction == "" {
+			out = append(out, map[string]any{r.relatedPK(): id})
+		} else {
+			out = append(out, map[string]any{r.junction: id})
+		}
+	}
+	for _, id := range remove {
+		if r.junction == "" {
+			if r.foreignKey == "" {
+				return nil, fmt.Errorf("detach items of %s: foreign key isn't set", r.field)
+			}
+			out = append(out, map[string]any{r.relatedPK(): id, r.foreignKey: nil})
+			continue
+		}
+		for _, row := range current {
+			if idString(row.related) == idString(id) {
+				out = append(out, map[string]any{r.rowPK(): row.id, "$delete": true})
+			}
+		}
+	}
+	return out, nil
+}
+
+// UpdateRelations attaches, detaches or replaces related items of an item
+// with given id. Currently related items are read first when removing M2M
+// items or replacing, because junction rows have to be deleted by their ids.
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (d API[R, W, PK]) UpdateRelations(ctx context.Context, id PK, updates ...RelationUpdate) (R, error) {
+	var empty R
+
+	fields := []string{}
+	for _, u := range updates {
+		if u.needsCurrent() {
+			fields = append(fields, u.currentFields()...)
+		}
+	}
+	var current map[string]any
+	if len(fields) > 0 {
+		uncached := d
+		uncached.Cache = nil
+		var err error
+		current, err = GetByIDAs[map[string]any](ctx, uncached, id, Fields(fields...))
+		if err != nil {
+			return empty, fmt.Errorf("read current relations: %w", err)
+		}
+	}
+
+	partials := map[string]any{}
+	for _, u := range updates {
+		payload, err := u.payload(u.relatedRows(current[u.field]))
+		if err != nil {
+			return empty, err
+		}
+		partials[u.field] = payload
+	}
+	return d.Update(ctx, id, partials)
+}
+
+// relatedRows reads related items from the decoded relation field
+func (r RelationUpdate) relatedRows(v any) []relatedRow {
+	list, _ := v.([]any)
+	rows := make([]relatedRow, 0, len(list))
+	for _, el := range list {
+		obj, ok := el.(map[string]any)
+		if !ok {
+			continue
+		}
+		row := relatedRow{obj[r.relatedPK()], obj[r.relatedPK()]}
+		if r.junction != "" {
+			row.id = obj[r.rowPK()]
+			row.related = obj[r.junction]
+			if expanded, ok := row.related.(map[string]any); ok {
+				row.related = expanded[r.relatedPK()]
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// idString normalizes a primary key for comparison
+func idString(id any) string {
+	s, _ := formatCell(id)
+	return s
+}
@@ -0,0 +1,80 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateRelations(t *testing.T) {
+	var patch map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "tags.id,tags.tag_id", r.URL.Query().Get("fields"))
+			fmt.Fprint(w, `{"data":{"tags":[{"id":10,"tag_id":1},{"id":11,"tag_id":{"id":2}}]}}`)
+		case http.MethodPatch:
+			b, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(b, &patch))
+			fmt.Fprint(w, `{"data":{"id":1}}`)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	_, err := api.UpdateRelations(context.Background(), 1,
+		Relations("tags").Via("tag_id").Replace(2, 3),
+		Relations("seeds").ForeignKey("fruit").Add(7).Remove(8),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{
+		map[string]any{"tag_id": float64(3)},
+		map[string]any{"id": float64(10), "$delete": true},
+	}, patch["tags"])
+	assert.Equal(t, []any{
+		map[string]any{"id": float64(7)},
+		map[string]any{"id": float64(8), "fruit": nil},
+	}, patch["seeds"])
+
+	_, err = api.UpdateRelations(context.Background(), 1, Relations("seeds").Remove(8))
+	assert.Error(t, err)
+}
+
+func TestUpdateRelationsPrimaryKeys(t *testing.T) {
+	var patch map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "tags.row_id,tags.tag_id,seeds.code", r.URL.Query().Get("fields"))
+			fmt.Fprint(w, `{"data":{"tags":[{"row_id":10,"tag_id":{"code":"a"}}],"seeds":[{"code":"x"}]}}`)
+		case http.MethodPatch:
+			b, _ := ioutil.ReadAll(r.Body)
+			require.NoError(t, json.Unmarshal(b, &patch))
+			fmt.Fprint(w, `{"data":{"id":1}}`)
+		}
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	_, err := api.UpdateRelations(context.Background(), 1,
+		Relations("tags").Via("tag_id").PrimaryKey("code").JunctionPrimaryKey("row_id").Remove("a"),
+		Relations("seeds").PrimaryKey("code").ForeignKey("fruit").Replace("y"),
+	)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{
+		map[string]any{"row_id": float64(10), "$delete": true},
+	}, patch["tags"])
+	assert.Equal(t, []any{
+		map[string]any{"code": "y"},
+		map[string]any{"code": "x", "fruit": nil},
+	}, patch["seeds"])
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,9 +12,64 @@ import (
 	"net/http/httputil"
 	"net/url"
 	"reflect"
+	"strings"
 )
 
-const tagName = "json"
+const (
+	tagName         = "json"
+	directusTagName = "directus"
+)
+
+// Error is returned when the server responds with an unexpected status
+type Error struct {
+	StatusCode int
+	Status     string
+	// Code is Directus error code, e.g. 203 for item not found
+	Code    int
+	Message string
+	Body    []byte
+}
+
+func newError(resp *http.Response, body []byte) *Error {
+	e := &Error{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+	var errBody struct {
+		Error struct {
+			Code    int    `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if json.Unmarshal(body, &errBody) == nil {
+		e.Code = errBody.Error.Code
+		e.Message = errBody.Error.Message
+	}
+	return e
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("unexpected status %s: %s", e.Status, string(e.Body))
+}
+
+// Directus error codes
+// https://v8.docs.directus.io/api/errors.html
+const (
+	errCodeItemNotFound  = 203
+	errCodeDuplicateItem = 204
+)
+
+// isUniqueViolation reports whether err is caused by a duplicate value of a unique field
+func isUniqueViolation(err error) bool {
+	var e *Error
+	if !errors.As(err, &e) {
+		return false
+	}
+	return e.StatusCode == http.StatusConflict ||
+		e.Code == errCodeDuplicateItem ||
+		strings.Contains(strings.ToLower(e.Message), "duplicate")
+}
 
 type request struct {
 	ctx    context.Context
@@ -28,11 +84,92 @@ func (a *API[R, W, PK]) executeRequest(r request, expectedStatus int, dest any)
 		return fmt.Errorf("dest has to be a pointer")
 	}
 
+	resp, err := a.sendRequest(r, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return newError(resp, respBytes)
+	}
+
+	if dest != nil {
+		err = json.NewDecoder(resp.Body).Decode(dest)
+		if err != nil {
+			return fmt.Errorf("decoding json response: %w", err)
+		}
+		readIn(dest, a.Location)
+	}
+
+	return nil
+}
+
+// streamRequest executes GET request and calls next for every element
+// of the response data array, next has to consume exactly one value
+func (a *API[R, W, PK]) streamRequest(r request, next func(dec *json.Decoder) error) error {
+	resp, err := a.sendRequest(r, nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBytes, _ := ioutil.ReadAll(resp.Body)
+		return newError(resp, respBytes)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("decoding json response: %w", err)
+		}
+		if tok != "data" {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return fmt.Errorf("decoding json response: %w", err)
+			}
+			continue
+		}
+		if err := expectDelim(dec, '['); err != nil {
+			return err
+		}
+		for dec.More() {
+			if err := next(dec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("decoding json response: %w", err)
+	}
+	if tok != delim {
+		return fmt.Errorf("decoding json response: expected %q, got %v", delim, tok)
+	}
+	return nil
+}
+
+// sendRequest builds and sends the request with extra header,
+// caller has to close the response body
+func (a *API[R, W, PK]) sendRequest(r request, header http.Header) (*http.Response, error) {
 	var b io.Reader
 	if r.body != nil {
-		bodyBytes, err := json.Marshal(r.body)
+		bodyBytes, err := a.encoder().marshal(r.body)
 		if err != nil {
-			return fmt.Errorf("marshal request body: %w", err)
+			return nil, fmt.Errorf("marshal request body: %w", err)
 		}
 		b = bytes.NewBuffer(bodyBytes)
 	}
@@ -44,17 +181,14 @@ func (a *API[R, W, PK]) executeRequest(r request, expectedStatus int, dest any)
 		b,
 	)
 	if err != nil {
-		return fmt.Errorf("create request: %w", err)
+		return nil, fmt.Errorf("create request: %w", err)
 	}
 
-	queryValues := url.Values{}
+	req.URL.RawQuery = encodeQuery(r.qv)
 
-	for k, v := range r.qv {
-		queryValues.Set(k, v)
+	for k, v := range header {
+		req.Header[k] = v
 	}
-
-	req.URL.RawQuery = queryValues.Encode()
-
 	req.Header.Set("Authorization", "Bearer "+a.BearerToken)
 	req.Header.Set("Content-Type", "application/json")
 
@@ -67,9 +201,8 @@ func (a *API[R, W, PK]) executeRequest(r request, expectedStatus int, dest any)
 
 	resp, err := a.HTTPClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("execute request: %v", err)
+		return nil, fmt.Errorf("execute request: %v", err)
 	}
-	defer resp.Body.Close()
 
 	if a.debug {
 		respDump, _ := httputil.DumpResponse(resp, true)
@@ -78,17 +211,13 @@ func (a *API[R, W, PK]) executeRequest(r request, expectedStatus int, dest any)
 		fmt.Println("--- Response end ---")
 	}
 
-	if resp.StatusCode != expectedStatus {
-		respBytes, _ := ioutil.ReadAll(resp.Body)
-		return fmt.Errorf("unexpected status %s: %s", resp.Status, string(respBytes))
-	}
+	return resp, nil
+}
 
-	if dest != nil {
-		err = json.NewDecoder(resp.Body).Decode(dest)
-		if err != nil {
-			return fmt.Errorf("decoding json response: %w", err)
-		}
+func encodeQuery(qv map[string]string) string {
+	queryValues := url.Values{}
+	for k, v := range qv {
+		queryValues.Set(k, v)
 	}
-
-	return nil
+	return queryValues.Encode()
 }
@@ -0,0 +1,134 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// CreateCollection creates a collection with its fields
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/collections.html#create-a-collection
+func (c Dynamic) CreateCollection(ctx context.Context, collection Record) error {
+	err := c.do(ctx, http.MethodPost, "collections", nil, collection, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("execute create collection request: %w", err)
+	}
+	return nil
+}
+
+// CollectionExists checks whether the collection with given name exists
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/collections.html#retrieve-a-collection
+func (c Dynamic) CollectionExists(ctx context.Context, name string) (bool, error) {
+	err := c.do(ctx, http.MethodGet, "collections/"+name, nil, nil, http.StatusOK, nil)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("execute get collection request: %w", err)
+	}
+	return true, nil
+}
+
+// DeleteCollection removes the collection with all its items
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/collections.html#delete-a-collection
+func (c Dynamic) DeleteCollection(ctx context.Context, name string) error {
+	err := c.do(ctx, http.MethodDelete, "collections/"+name, nil, nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute delete collection request: %w", err)
+	}
+	return nil
+}
+
+// CreateField adds a field to the collection
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/fields.html#create-a-field
+func (c Dynamic) CreateField(ctx context.Context, collection string, field Record) error {
+	err := c.do(ctx, http.MethodPost, "fields/"+collection, nil, field, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("execute create field request: %w", err)
+	}
+	return nil
+}
+
+// UpdateField changes definition of the field
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/fields.html#update-a-field
+func (c Dynamic) UpdateField(ctx context.Context, collection, field string, changes Record) error {
+	err := c.do(ctx, http.MethodPatch, "fields/"+collection+"/"+field, nil, changes, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("execute update field request: %w", err)
+	}
+	return nil
+}
+
+// DeleteField removes the field from the collection
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/fields.html#delete-a-field
+func (c Dynamic) DeleteField(ctx context.Context, collection, field string) error {
+	err := c.do(ctx, http.MethodDelete, "fields/"+collection+"/"+field, nil, nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute delete field request: %w", err)
+	}
+	return nil
+}
+
+// CreateRelation creates a relation between collections
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/relations.html#create-a-relation
+func (c Dynamic) CreateRelation(ctx context.Context, relation Record) error {
+	err := c.do(ctx, http.MethodPost, "relations", nil, relation, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("execute create relation request: %w", err)
+	}
+	return nil
+}
+
+// DeleteRelation removes the relation of the many side field
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/relations.html#delete-a-relation
+func (c Dynamic) DeleteRelation(ctx context.Context, collectionMany, fieldMany string) error {
+	var respBody struct {
+		Data []Record `json:"data"`
+	}
+	qv := Eq("collection_many", collectionMany).Eq("field_many", fieldMany).asKeyValue()
+	err := c.do(ctx, http.MethodGet, "relations", qv, nil, http.StatusOK, &respBody)
+	if err != nil {
+		return fmt.Errorf("execute get relations request: %w", err)
+	}
+	if len(respBody.Data) == 0 {
+		return fmt.Errorf("relation of %s.%s not found", collectionMany, fieldMany)
+	}
+	id := idString(respBody.Data[0]["id"])
+	err = c.do(ctx, http.MethodDelete, "relations/"+id, nil, nil, http.StatusNoContent, nil)
+	if err != nil {
+		return fmt.Errorf("execute delete relation request: %w", err)
+	}
+	return nil
+}
+
+// do executes request to the project endpoint under path
+func (c Dynamic) do(ctx context.Context, method, path string, qv map[string]string, body any, expectedStatus int, dest any) error {
+	d := c.Collection("")
+	u := fmt.Sprintf("%s://%s/%s/%s", d.Scheme, d.Host, d.Namespace, path)
+	req := request{
+		ctx,
+		method,
+		u,
+		qv,
+		body,
+	}
+	return d.executeRequest(req, expectedStatus, dest)
+}
@@ -0,0 +1,140 @@
+package directusapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Singleton is a generic API client for a Directus single collection (e.g. settings)
+// R is a read model
+// W is a write model
+type Singleton[R, W any] struct {
+	Scheme          string
+	Host            string
+	Namespace       string
+	CollectionName  string
+	BearerToken     string
+	HTTPClient      *http.Client
+	PrimaryKeyField string         // name of the primary key field, defaults to "id"
+	Location        *time.Location // time zone of the server, defaults to UTC
+	NullZeroTime    bool           // zero directusapi.Time is written as null
+	debug           bool
+}
+
+func (s Singleton[R, W]) api() API[R, W, string] {
+	return API[R, W, string]{
+		Scheme:          s.Scheme,
+		Host:            s.Host,
+		Namespace:       s.Namespace,
+		CollectionName:  s.CollectionName,
+		BearerToken:     s.BearerToken,
+		HTTPClient:      s.HTTPClient,
+		PrimaryKeyField: s.PrimaryKeyField,
+		Location:        s.Location,
+		NullZeroTime:    s.NullZeroTime,
+		debug:           s.debug,
+	}
+}
+
+// Get reads the item of the single collection
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#list-the-items
+func (s Singleton[R, W]) Get(ctx context.Context) (R, error) {
+	d := s.api()
+	var empty R
+	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+	fields, err := d.jsonFieldsR()
+	if err != nil {
+		return empty, err
+	}
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		map[string]string{
+			"fields": strings.Join(fields, ","),
+			"single": "1",
+		},
+		nil,
+	}
+
+	var respBody struct {
+		Data R `json:"data"`
+	}
+	err = d.executeRequest(req, http.StatusOK, &respBody)
+	if err != nil {
+		return empty, fmt.Errorf("execute get single request: %w", err)
+	}
+	return respBody.Data, nil
+}
+
+// Set performs an update of the item, it's created when the collection is empty
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (s Singleton[R, W]) Set(ctx context.Context, item W) (R, error) {
+	d := s.api()
+	var empty R
+	id, ok, err := s.id(ctx)
+	if err != nil {
+		return empty, err
+	}
+	if !ok {
+		return d.Insert(ctx, item)
+	}
+	return d.Set(ctx, id, item)
+}
+
+// Update performs partial update of the item, it's created when the collection is empty
+//
+// Related Directus reference:
+// https://v8.docs.directus.io/api/items.html#update-an-item
+func (s Singleton[R, W]) Update(ctx context.Context, partials map[string]any) (R, error) {
+	d := s.api()
+	var empty R
+	id, ok, err := s.id(ctx)
+	if err != nil {
+		return empty, err
+	}
+	if !ok {
+		return d.Create(ctx, partials)
+	}
+	return d.Update(ctx, id, partials)
+}
+
+// id reads primary key of the item, ok is false when the collection is empty
+func (s Singleton[R, W]) id(ctx context.Context) (string, bool, error) {
+	d := s.api()
+	u := fmt.Sprintf("%s://%s/%s/items/%s", d.Scheme, d.Host, d.Namespace, d.CollectionName)
+
+	req := request{
+		ctx,
+		http.MethodGet,
+		u,
+		map[string]string{
+			"fields": d.pkField(),
+			"single": "1",
+		},
+		nil,
+	}
+
+	var respBody struct {
+		Data map[string]any `json:"data"`
+	}
+	err := d.executeRequest(req, http.StatusOK, &respBody)
+	var apiErr *Error
+	if errors.As(err, &apiErr) && (apiErr.StatusCode == http.StatusNotFound || apiErr.Code == errCodeItemNotFound) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("execute get single id request: %w", err)
+	}
+	id := idString(respBody.Data[d.pkField()])
+	return id, id != "", nil
+}
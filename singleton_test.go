@@ -0,0 +1,66 @@
+package directusapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleton(t *testing.T) {
+	type settings struct {
+		ID       int    `json:"id"`
+		SiteName string `json:"site_name"`
+	}
+
+	stored := false
+	requests := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "1", r.URL.Query().Get("single"))
+			if !stored {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprint(w, `{"error":{"code":203,"message":"Item not found"}}`)
+				return
+			}
+			fmt.Fprint(w, `{"data":{"id":5,"site_name":"fruits"}}`)
+		default:
+			stored = true
+			fmt.Fprint(w, `{"data":{"id":5,"site_name":"fruits"}}`)
+		}
+	}))
+	defer srv.Close()
+
+	u, _ := url.Parse(srv.URL)
+	s := Singleton[settings, settings]{
+		Scheme:         u.Scheme,
+		Host:           u.Host,
+		Namespace:      "_",
+		CollectionName: "settings",
+		HTTPClient:     srv.Client(),
+	}
+	ctx := context.Background()
+
+	_, err := s.Set(ctx, settings{SiteName: "fruits"})
+	require.NoError(t, err)
+	_, err = s.Update(ctx, map[string]any{"site_name": "fruits"})
+	require.NoError(t, err)
+	current, err := s.Get(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, settings{5, "fruits"}, current)
+
+	assert.Equal(t, []string{
+		"GET /_/items/settings",
+		"POST /_/items/settings",
+		"GET /_/items/settings",
+		"PATCH /_/items/settings/5",
+		"GET /_/items/settings",
+	}, requests)
+}
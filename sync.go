@@ -0,0 +1,273 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+const defaultSyncBatchSize = 100
+
+// SyncOptions configures PlanSync
+type SyncOptions struct {
+	// KeyField matches items of both collections, defaults to the primary key
+	// When a natural key is used, primary keys aren't compared nor copied.
+	KeyField string
+	// Ignore are fields excluded from comparison and writes, e.g. modified_on
+	Ignore []string
+	// Delete plans deletes of target items missing in the source collection
+	Delete bool
+}
+
+// SyncPlan is a list of changes making the target collection equal to the source
+type SyncPlan struct {
+	Inserts   []SyncChange
+	Updates   []SyncChange
+	Deletes   []SyncChange
+	Conflicts []SyncConflict
+}
+
+// SyncChange is a planned change of a single item
+type SyncChange struct {
+	Key string
+	// TargetID is the primary key of the target item, empty for inserts
+	TargetID string
+	// Values are written to the target, whole item for inserts,
+	// changed fields for updates and nil for deletes
+	Values Record
+}
+
+// SyncConflict is an item which can't be synchronized
+type SyncConflict struct {
+	Key    string
+	Reason string
+}
+
+// SyncResult is a summary of ApplySync
+type SyncResult struct {
+	Inserted int
+	Updated  int
+	Deleted  int
+	Errors   []SyncError
+}
+
+// SyncError is a failed change of a single item
+type SyncError struct {
+	Key string
+	Op  string
+	Err error
+}
+
+func (e SyncError) Error() string {
+	return fmt.Sprintf("%s %s: %s", e.Op, e.Key, e.Err)
+}
+
+func (e SyncError) Unwrap() error {
+	return e.Err
+}
+
+// PlanSync compares items of the source and target collection and plans
+// inserts, updates and deletes of the target. Items with a missing or
+// duplicate key are reported as conflicts and left untouched.
+func PlanSync(ctx context.Context, source, target API[Record, Record, string], opts SyncOptions) (SyncPlan, error) {
+	plan := SyncPlan{}
+	keyField := opts.KeyField
+	if keyField == "" {
+		keyField = target.pkField()
+	}
+	skip := map[string]bool{}
+	for _, f := range opts.Ignore {
+		skip[f] = true
+	}
+	if keyField != target.pkField() {
+		skip[target.pkField()] = true
+	}
+
+	srcItems, err := loadByKey(ctx, source, keyField, &plan)
+	if err != nil {
+		return plan, fmt.Errorf("read source items: %w", err)
+	}
+	dstItems, err := loadByKey(ctx, target, keyField, &plan)
+	if err != nil {
+		return plan, fmt.Errorf("read target items: %w", err)
+	}
+
+	for _, key := range sortedKeys(srcItems) {
+		src := srcItems[key]
+		dst, ok := dstItems[key]
+		if !ok {
+			plan.Inserts = append(plan.Inserts, SyncChange{
+				Key:    key,
+				Values: withoutFields(src, skip),
+			})
+			continue
+		}
+		changed := Record{}
+		for field, v := range src {
+			if skip[field] {
+				continue
+			}
+			if !sameValue(v, dst[field]) {
+				changed[field] = v
+			}
+		}
+		if len(changed) > 0 {
+			plan.Updates = append(plan.Updates, SyncChange{
+				Key:      key,
+				TargetID: idString(dst[target.pkField()]),
+				Values:   changed,
+			})
+		}
+	}
+
+	if opts.Delete {
+		for _, key := range sortedKeys(dstItems) {
+			if _, ok := srcItems[key]; !ok {
+				plan.Deletes = append(plan.Deletes, SyncChange{
+					Key:      key,
+					TargetID: idString(dstItems[key][target.pkField()]),
+				})
+			}
+		}
+	}
+	return plan, nil
+}
+
+// ApplySync applies the plan to the target collection, inserts, updates
+// and deletes are written in batches. Failed changes are reported in the result.
+func ApplySync(ctx context.Context, target API[Record, Record, string], plan SyncPlan, batchSize int) (SyncResult, error) {
+	if batchSize <= 0 {
+		batchSize = defaultSyncBatchSize
+	}
+	res := SyncResult{}
+
+	for _, batch := range syncBatches(plan.Inserts, batchSize) {
+		items := make([]Record, len(batch))
+		for i, c := range batch {
+			items[i] = c.Values
+		}
+		if _, err := target.InsertMany(ctx, items); err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			res.Errors = append(res.Errors, batchErrors(batch, "insert", err)...)
+			continue
+		}
+		res.Inserted += len(batch)
+	}
+
+	for _, batch := range syncBatches(plan.Updates, batchSize) {
+		partials := make([]map[string]any, len(batch))
+		for i, c := range batch {
+			partials[i] = map[string]any{target.pkField(): c.TargetID}
+			for field, v := range c.Values {
+				partials[i][field] = v
+			}
+		}
+		if _, err := target.UpdateMany(ctx, partials); err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			res.Errors = append(res.Errors, batchErrors(batch, "update", err)...)
+			continue
+		}
+		res.Updated += len(batch)
+	}
+
+	for _, batch := range syncBatches(plan.Deletes, batchSize) {
+		ids := make([]string, len(batch))
+		for i, c := range batch {
+			ids[i] = c.TargetID
+		}
+		if err := target.DeleteMany(ctx, ids); err != nil {
+			if ctx.Err() != nil {
+				return res, ctx.Err()
+			}
+			res.Errors = append(res.Errors, batchErrors(batch, "delete", err)...)
+			continue
+		}
+		res.Deleted += len(batch)
+	}
+	return res, nil
+}
+
+// loadByKey reads all items of the collection keyed by keyField,
+// items of all statuses are read including soft deleted ones
+func loadByKey(ctx context.Context, d API[Record, Record, string], keyField string, plan *SyncPlan) (map[string]Record, error) {
+	items := map[string]Record{}
+	duplicates := map[string]bool{}
+	err := d.StreamItems(ctx, Limit(-1).Status("*"), func(item Record) error {
+		key := idString(item[keyField])
+		if key == "" {
+			plan.Conflicts = append(plan.Conflicts, SyncConflict{
+				Key:    idString(item[d.pkField()]),
+				Reason: fmt.Sprintf("%s item has empty %s", d.Host, keyField),
+			})
+			return nil
+		}
+		if _, ok := items[key]; ok {
+			duplicates[key] = true
+		}
+		items[key] = item
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range sortedKeys(duplicates) {
+		delete(items, key)
+		plan.Conflicts = append(plan.Conflicts, SyncConflict{
+			Key:    key,
+			Reason: fmt.Sprintf("%s has duplicate %s", d.Host, keyField),
+		})
+	}
+	return items, nil
+}
+
+// sameValue compares values by their JSON representation
+func sameValue(a, b any) bool {
+	aj, errA := json.Marshal(a)
+	bj, errB := json.Marshal(b)
+	return errA == nil && errB == nil && string(aj) == string(bj)
+}
+
+func withoutFields(r Record, skip map[string]bool) Record {
+	out := make(Record, len(r))
+	for k, v := range r {
+		if !skip[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func syncBatches(changes []SyncChange, size int) [][]SyncChange {
+	batches := [][]SyncChange{}
+	for len(changes) > 0 {
+		n := size
+		if n > len(changes) {
+			n = len(changes)
+		}
+		batches = append(batches, changes[:n])
+		changes = changes[n:]
+	}
+	return batches
+}
+
+func batchErrors(batch []SyncChange, op string, err error) []SyncError {
+	out := make([]SyncError, len(batch))
+	for i, c := range batch {
+		out[i] = SyncError{c.Key, op, err}
+	}
+	return out
+}
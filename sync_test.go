@@ -0,0 +1,86 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSync(t *testing.T) {
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "*", r.URL.Query().Get("status"))
+		fmt.Fprint(w, `{"data":[
+			{"id":1,"name":"apple","weight":3,"modified_on":"2022-01-01 00:00:00"},
+			{"id":2,"name":"pear","weight":2,"modified_on":"2022-01-01 00:00:00"},
+			{"id":3,"name":"plum","weight":1,"modified_on":"2022-01-01 00:00:00"},
+			{"id":4,"name":"fig","weight":1},
+			{"id":5,"name":"fig","weight":2}
+		]}`)
+	}))
+	defer source.Close()
+
+	writes := []string{}
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			assert.Equal(t, "*", r.URL.Query().Get("status"))
+			fmt.Fprint(w, `{"data":[
+				{"id":10,"name":"apple","weight":3,"modified_on":"2022-02-02 00:00:00"},
+				{"id":11,"name":"pear","weight":5},
+				{"id":12,"name":"kiwi","weight":1},
+				{"id":13,"name":"lime","weight":1}
+			]}`)
+			return
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		writes = append(writes, r.Method+" "+r.URL.Path+" "+string(b))
+		switch r.Method {
+		case http.MethodPost:
+			var items []Record
+			require.NoError(t, json.Unmarshal(b, &items))
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": items})
+		case http.MethodPatch:
+			fmt.Fprint(w, `{"data":[]}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer target.Close()
+
+	collection := func(srv *httptest.Server) API[Record, Record, string] {
+		u, _ := url.Parse(srv.URL)
+		return Dynamic{Scheme: u.Scheme, Host: u.Host, Namespace: "_", HTTPClient: srv.Client()}.Collection("fruits")
+	}
+	ctx := context.Background()
+
+	plan, err := PlanSync(ctx, collection(source), collection(target), SyncOptions{
+		KeyField: "name",
+		Ignore:   []string{"modified_on"},
+		Delete:   true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, plan.Inserts, 1)
+	assert.Equal(t, "plum", plan.Inserts[0].Key)
+	require.Len(t, plan.Updates, 1)
+	assert.Equal(t, "11", plan.Updates[0].TargetID)
+	assert.Equal(t, Record{"weight": json.Number("2")}, plan.Updates[0].Values)
+	require.Len(t, plan.Deletes, 2)
+	assert.Equal(t, []SyncConflict{{"fig", source.Listener.Addr().String() + " has duplicate name"}}, plan.Conflicts)
+
+	res, err := ApplySync(ctx, collection(target), plan, 10)
+	require.NoError(t, err)
+	assert.Equal(t, SyncResult{Inserted: 1, Updated: 1, Deleted: 2}, res)
+	assert.Equal(t, []string{
+		`POST /_/items/fruits [{"name":"plum","weight":1}]`,
+		`PATCH /_/items/fruits [{"id":"11","weight":2}]`,
+		`DELETE /_/items/fruits/12,13 `,
+	}, writes)
+}
@@ -1,12 +1,49 @@
 package directusapi
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
-const datetimeFormat = "2006-01-02 15:04:05"
+const (
+	datetimeFormat = "2006-01-02 15:04:05"
+	dateFormat     = "2006-01-02"
+	timeFormat     = "15:04:05"
+)
+
+// datetimeLayouts are accepted datetime formats without a time zone,
+// such values are in the server's time zone (see API.Location)
+var datetimeLayouts = []string{
+	datetimeFormat,
+	"2006-01-02T15:04:05",
+	dateFormat,
+}
+
+// zonedLayouts are accepted datetime formats with a time zone offset,
+// RFC 3339 is used by Directus v9 and by v8 projects with ISO 8601 formatting
+var zonedLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05Z07:00",
+}
 
+// explicitUTC is the location of values with "Z" offset, it makes them distinct
+// from values without a time zone, which are parsed as time.UTC
+var explicitUTC = time.FixedZone("UTC", 0)
+
+// zeroDates are MySQL zero values, they are decoded as zero Time
+var zeroDates = map[string]bool{
+	"":                    true,
+	"0000-00-00":          true,
+	"0000-00-00 00:00:00": true,
+}
+
+// Time is a Directus datetime field
+// Values without a time zone are parsed as UTC unless API.Location is set,
+// values with an offset (e.g. RFC 3339) keep it.
+// Null and zero dates are decoded as zero Time, zero Time is written
+// as null when API.NullZeroTime is set.
 type Time struct {
 	time.Time
 }
@@ -17,10 +54,184 @@ func (t Time) MarshalJSON() ([]byte, error) {
 }
 
 func (t *Time) UnmarshalJSON(data []byte) error {
-	parsedT, err := time.Parse(fmt.Sprintf("\"%s\"", datetimeFormat), string(data))
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return t.UnmarshalText([]byte(s))
+}
+
+// MarshalText formats Time in Directus datetime format, zero Time is an empty text
+func (t Time) MarshalText() ([]byte, error) {
+	if t.IsZero() {
+		return []byte{}, nil
+	}
+	return []byte(t.Format(datetimeFormat)), nil
+}
+
+// UnmarshalText parses Time in any of the accepted formats
+func (t *Time) UnmarshalText(data []byte) error {
+	s := string(data)
+	if zeroDates[s] {
+		t.Time = time.Time{}
+		return nil
+	}
+	parsedT, err := parseTime(s)
 	if err != nil {
 		return err
 	}
 	t.Time = parsedT
 	return nil
 }
+
+// Scan implements sql.Scanner, NULL and zero dates are scanned as zero Time
+func (t *Time) Scan(src any) error {
+	switch v := src.(type) {
+	case nil:
+		t.Time = time.Time{}
+		return nil
+	case time.Time:
+		t.Time = v
+		return nil
+	case string:
+		return t.UnmarshalText([]byte(v))
+	case []byte:
+		return t.UnmarshalText(v)
+	default:
+		return fmt.Errorf("scan %T into directusapi.Time", src)
+	}
+}
+
+// Value implements driver.Valuer, zero Time is NULL
+func (t Time) Value() (driver.Value, error) {
+	if t.IsZero() {
+		return nil, nil
+	}
+	return t.Time, nil
+}
+
+// readIn reinterprets value without a time zone in the server's location
+func (t *Time) readIn(loc *time.Location) {
+	if t.IsZero() || t.Location() != time.UTC {
+		return
+	}
+	t.Time = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// encodeBody converts value to the server's location, UTC by default
+func (t Time) encodeBody(e encoder) (any, error) {
+	if t.IsZero() {
+		if e.nullZeroTime {
+			return nil, nil
+		}
+		return rawJSON(t)
+	}
+	loc := e.loc
+	if loc == nil {
+		loc = time.UTC
+	}
+	return rawJSON(Time{t.In(loc)})
+}
+
+func (t Time) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
+
+// parseTime parses datetime in any of the accepted layouts
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range zonedLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			if t.Location() == time.UTC {
+				t = t.In(explicitUTC)
+			}
+			return t, nil
+		}
+	}
+	for _, layout := range datetimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("parsing time %q: unknown datetime format", s)
+}
+
+// Date is a Directus date field, time of the day is always midnight UTC
+// Null and zero dates are decoded as zero Date.
+type Date struct {
+	time.Time
+}
+
+func (d Date) MarshalJSON() ([]byte, error) {
+	stamp := fmt.Sprintf("\"%s\"", d.Format(dateFormat))
+	return []byte(stamp), nil
+}
+
+func (d *Date) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		d.Time = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if zeroDates[s] {
+		d.Time = time.Time{}
+		return nil
+	}
+	if len(s) > len(dateFormat) {
+		// datetime values are truncated to their date
+		s = s[:len(dateFormat)]
+	}
+	parsedD, err := time.Parse(dateFormat, s)
+	if err != nil {
+		return err
+	}
+	d.Time = parsedD
+	return nil
+}
+
+func (d Date) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
+
+// TimeOfDay is a Directus time field, the date is always January 1, year 0 UTC
+// Null is decoded as zero TimeOfDay.
+type TimeOfDay struct {
+	time.Time
+}
+
+func (t TimeOfDay) MarshalJSON() ([]byte, error) {
+	stamp := fmt.Sprintf("\"%s\"", t.Format(timeFormat))
+	return []byte(stamp), nil
+}
+
+func (t *TimeOfDay) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		t.Time = time.Time{}
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		t.Time = time.Time{}
+		return nil
+	}
+	for _, layout := range []string{timeFormat, "15:04"} {
+		if parsedT, err := time.Parse(layout, s); err == nil {
+			t.Time = parsedT
+			return nil
+		}
+	}
+	return fmt.Errorf("parsing time of day %q: unknown time format", s)
+}
+
+func (t TimeOfDay) fields(prefix string) ([]string, error) {
+	return []string{prefix}, nil
+}
@@ -0,0 +1,146 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeUnmarshal(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected time.Time
+	}{
+		{`"2022-05-05 10:30:00"`, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)},
+		{`"2022-05-05T10:30:00"`, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)},
+		{`"2022-05-05"`, time.Date(2022, 5, 5, 0, 0, 0, 0, time.UTC)},
+		{`"2022-05-05T10:30:00.123Z"`, time.Date(2022, 5, 5, 10, 30, 0, 123000000, time.UTC)},
+		{`"2022-05-05T12:30:00+02:00"`, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)},
+		{`"2022-05-05 12:30:00+02:00"`, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		var ts Time
+		require.NoError(t, json.Unmarshal([]byte(tt.in), &ts), tt.in)
+		assert.True(t, tt.expected.Equal(ts.Time), "%s: %s", tt.in, ts)
+	}
+
+	var ts Time
+	assert.Error(t, json.Unmarshal([]byte(`"05/05/2022"`), &ts))
+}
+
+func TestDateAndTimeOfDay(t *testing.T) {
+	var d struct {
+		Day   Date      `json:"day"`
+		Opens TimeOfDay `json:"opens"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"day":"2022-05-05T00:00:00Z","opens":"08:30"}`), &d))
+	assert.Equal(t, time.Date(2022, 5, 5, 0, 0, 0, 0, time.UTC), d.Day.Time)
+	assert.Equal(t, 8, d.Opens.Hour())
+	assert.Equal(t, 30, d.Opens.Minute())
+
+	b, err := json.Marshal(d)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"day":"2022-05-05","opens":"08:30:00"}`, string(b))
+
+	fields, err := fieldsOf(reflect.TypeOf(d))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"day", "opens"}, fields)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"day":null,"opens":null}`), &d))
+	assert.True(t, d.Day.IsZero())
+	assert.True(t, d.Opens.IsZero())
+	d.Day = Date{time.Now()}
+	require.NoError(t, json.Unmarshal([]byte(`{"day":"0000-00-00"}`), &d))
+	assert.True(t, d.Day.IsZero())
+}
+
+func TestLocation(t *testing.T) {
+	prague, err := time.LoadLocation("Europe/Prague")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPatch {
+			body, _ := ioutil.ReadAll(r.Body)
+			assert.Contains(t, string(body), `"discovered_at":"2022-05-05 12:30:00"`)
+		}
+		w.Write([]byte(`{"data":{"id":1,"name":"apple","discovered_at":"2022-05-05 12:30:00"}}`))
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	api.Location = prague
+	ctx := context.Background()
+
+	apple, err := api.GetByID(ctx, 1)
+	require.NoError(t, err)
+	discovered := apple.DiscoveredAt.ValueMust()
+	assert.True(t, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC).Equal(discovered.Time))
+
+	_, err = api.Set(ctx, 1, FruitW{
+		Name:         "apple",
+		DiscoveredAt: Time{time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC)},
+	})
+	require.NoError(t, err)
+}
+
+func TestZonedTimeWrittenInUTC(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"discovered_at":"2020-01-01 08:00:00"`)
+		w.Write([]byte(`{"data":{"id":1,"name":"apple"}}`))
+	}))
+	defer srv.Close()
+
+	var discovered Time
+	require.NoError(t, json.Unmarshal([]byte(`"2020-01-01T10:00:00+02:00"`), &discovered))
+	_, err := testAPI(srv).Set(context.Background(), 1, FruitW{Name: "apple", DiscoveredAt: discovered})
+	require.NoError(t, err)
+}
+
+func TestTimeNullAndZero(t *testing.T) {
+	for _, in := range []string{`null`, `""`, `"0000-00-00"`, `"0000-00-00 00:00:00"`} {
+		ts := Time{time.Now()}
+		require.NoError(t, json.Unmarshal([]byte(in), &ts), in)
+		assert.True(t, ts.IsZero(), in)
+	}
+
+	var ts Time
+	require.NoError(t, ts.Scan([]byte("2022-05-05 10:30:00")))
+	assert.Equal(t, time.Date(2022, 5, 5, 10, 30, 0, 0, time.UTC), ts.Time)
+	v, err := ts.Value()
+	require.NoError(t, err)
+	assert.Equal(t, ts.Time, v)
+	text, err := ts.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "2022-05-05 10:30:00", string(text))
+
+	require.NoError(t, ts.Scan(nil))
+	assert.True(t, ts.IsZero())
+	v, err = ts.Value()
+	require.NoError(t, err)
+	assert.Nil(t, v)
+}
+
+func TestNullZeroTime(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Contains(t, string(body), `"discovered_at":null`)
+		w.Write([]byte(`{"data":{"id":1,"name":"apple","discovered_at":null}}`))
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	api.NullZeroTime = true
+	apple, err := api.Insert(context.Background(), FruitW{Name: "apple"})
+	require.NoError(t, err)
+	assert.False(t, apple.DiscoveredAt.IsSet())
+}
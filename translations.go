@@ -0,0 +1,104 @@
+package directusapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// translationLanguageField is a field of translation rows holding the language code
+const translationLanguageField = "language"
+
+// Translations is a translations relation decoded into a map keyed by language code
+// Every translation row is decoded into T, the language field of the row
+// can be either the code or an expanded language with the code field.
+type Translations[T any] map[string]T
+
+// Get returns translation in the first available language of lang and fallbacks
+func (t Translations[T]) Get(lang string, fallbacks ...string) (T, bool) {
+	for _, l := range append([]string{lang}, fallbacks...) {
+		if v, ok := t[l]; ok {
+			return v, true
+		}
+	}
+	var empty T
+	return empty, false
+}
+
+func (t *Translations[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*t = nil
+		return nil
+	}
+
+	var rows []json.RawMessage
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return err
+	}
+	out := make(Translations[T], len(rows))
+	for i, row := range rows {
+		var lang struct {
+			Language json.RawMessage `json:"language"`
+		}
+		if err := json.Unmarshal(row, &lang); err != nil {
+			return err
+		}
+		code, err := languageCode(lang.Language)
+		if err != nil {
+			return fmt.Errorf("translation %d: %w", i, err)
+		}
+		var v T
+		if err := json.Unmarshal(row, &v); err != nil {
+			return err
+		}
+		out[code] = v
+	}
+	*t = out
+	return nil
+}
+
+func (t Translations[T]) MarshalJSON() ([]byte, error) {
+	if t == nil {
+		return []byte(`null`), nil
+	}
+
+	codes := make([]string, 0, len(t))
+	for code := range t {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	rows := make([]map[string]json.RawMessage, 0, len(t))
+	for _, code := range codes {
+		b, err := json.Marshal(t[code])
+		if err != nil {
+			return nil, err
+		}
+		row := map[string]json.RawMessage{}
+		if err := json.Unmarshal(b, &row); err != nil {
+			return nil, err
+		}
+		row[translationLanguageField], _ = json.Marshal(code)
+		rows = append(rows, row)
+	}
+	return json.Marshal(rows)
+}
+
+func (t Translations[T]) fields(prefix string) ([]string, error) {
+	return []string{prefix + ".*"}, nil
+}
+
+// languageCode reads the code from a language field
+func languageCode(raw json.RawMessage) (string, error) {
+	var code string
+	if err := json.Unmarshal(raw, &code); err == nil {
+		return code, nil
+	}
+	var lang struct {
+		Code string `json:"code"`
+	}
+	if err := json.Unmarshal(raw, &lang); err != nil || lang.Code == "" {
+		return "", fmt.Errorf("missing %s code", translationLanguageField)
+	}
+	return lang.Code, nil
+}
@@ -0,0 +1,49 @@
+package directusapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTranslations(t *testing.T) {
+	type fruitText struct {
+		Title string `json:"title"`
+	}
+	type fruitL10n struct {
+		ID           int                     `json:"id"`
+		Translations Translations[fruitText] `json:"translations"`
+	}
+
+	var query map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"data":{"id":1,"translations":[
+			{"id":1,"language":"en-US","title":"Apple"},
+			{"id":2,"language":{"code":"cs-CZ"},"title":"Jablko"}
+		]}}`)
+	}))
+	defer srv.Close()
+
+	api := testAPI(srv)
+	apple, err := GetByIDAs[fruitL10n](context.Background(), api, 1, Lang("cs-CZ"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"id,translations.*"}, query["fields"])
+	assert.Equal(t, []string{"cs-CZ"}, query["lang"])
+
+	text, ok := apple.Translations.Get("de-DE", "cs-CZ", "en-US")
+	assert.True(t, ok)
+	assert.Equal(t, "Jablko", text.Title)
+	_, ok = apple.Translations.Get("de-DE")
+	assert.False(t, ok)
+
+	b, err := json.Marshal(apple.Translations)
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"language":"cs-CZ","title":"Jablko"},{"language":"en-US","title":"Apple"}]`, string(b))
+}